@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// activitiesEnv — переменная окружения с путем к файлу собственных типов
+// тренировок.
+const activitiesEnv = "TRACKER_ACTIVITIES"
+
+// loadActivities регистрирует собственные типы тренировок из файла,
+// указанного в TRACKER_ACTIVITIES, или из ~/.tracker/activities.yaml.
+// Отсутствие файла по умолчанию ошибкой не считается.
+func loadActivities() error {
+	path, explicit := os.LookupEnv(activitiesEnv)
+	if !explicit || path == "" {
+		explicit = false
+		path = defaultActivitiesPath()
+	}
+	if path == "" {
+		return nil
+	}
+
+	c, err := spentcalories.LoadConfig(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+
+	return spentcalories.RegisterConfig(c)
+}
+
+// defaultActivitiesPath возвращает путь к файлу типов тренировок
+// по умолчанию: ~/.tracker/activities.yaml.
+func defaultActivitiesPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".tracker", "activities.yaml")
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/batch"
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// runDay выводит отчеты о дневной активности.
+func runDay(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("day", flag.ContinueOnError)
+	profileArgs := addProfileFlags(fs)
+	output := addOutputFlags(fs)
+	system := addUnitsFlag(fs)
+	cumulative := fs.Bool("cumulative", false, "пакеты — накопленные показания шагомера вида \"12:40:00,3456\"; выводится итог дня")
+	date := fs.String("date", "", "день показаний шагомера в формате 2006-01-02 (по умолчанию — сегодня)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	user, err := profileArgs.load(*system)
+	if err != nil {
+		return err
+	}
+
+	if *cumulative {
+		return runDaySession(fs.Args(), stdin, stdout, user, output, *date)
+	}
+
+	p, err := output.processor(batch.Days(user), user.Units)
+	if err != nil {
+		return err
+	}
+
+	p.OnError = func(err *batch.LineError) {
+		log.Println(packetError(err.Data, err.Err))
+	}
+
+	return processPackets(fs.Args(), stdin, stdout, p)
+}
+
+// runDaySession считает итог дня по накопленным показаниям шагомера.
+// Отклоненные показания и сбросы счетчика выводятся в лог.
+func runDaySession(paths []string, stdin io.Reader, stdout io.Writer, user profile.Profile, output *outputFlags, date string) error {
+	day := time.Now()
+	if date != "" {
+		var err error
+		if day, err = time.ParseInLocation("2006-01-02", date, time.Local); err != nil {
+			return fmt.Errorf("неверная дата: %w", err)
+		}
+	}
+
+	session, err := daysteps.NewSession(day, user)
+	if err != nil {
+		return err
+	}
+
+	enc, err := output.encoder(stdout, user.Units)
+	if err != nil {
+		return err
+	}
+
+	err = readPackets(paths, stdin, func(data string) error {
+		delta, err := session.Add(data)
+		if err != nil {
+			log.Printf("показание отклонено: %v", packetError(data, err))
+			return nil
+		}
+		if delta.Reset {
+			log.Printf("сброс счетчика шагомера в %s", delta.Time.Format("15:04:05"))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	total, err := session.Total()
+	if err != nil {
+		return err
+	}
+	return enc.Encode(total)
+}
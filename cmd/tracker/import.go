@@ -0,0 +1,126 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Yandex-Practicum/tracker/internal/csvimport"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// runImport загружает CSV-экспорт в журнал активности. Ошибки в отдельных
+// строках выводятся в лог и не прерывают импорт.
+func runImport(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	path := fs.String("journal", defaultJournalPath(), "файл журнала")
+	columns := fs.String("columns", "steps=steps,activity=activity,duration=duration,time=time",
+		"соответствие полей столбцам CSV: time, steps, activity, duration, param")
+	comma := fs.String("comma", ",", "разделитель полей CSV")
+	layout := fs.String("time-layout", time.RFC3339, "формат времени в столбце time")
+	profileArgs := addProfileFlags(fs)
+	system := addUnitsFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	user, err := profileArgs.load(*system)
+	if err != nil {
+		return err
+	}
+
+	cols, err := csvimport.ParseColumns(*columns)
+	if err != nil {
+		return err
+	}
+
+	sep, size := utf8.DecodeRuneInString(*comma)
+	if size == 0 || size != len(*comma) {
+		return fmt.Errorf("разделитель должен быть одним символом, получено %q", *comma)
+	}
+
+	j, err := journal.Open(*path)
+	if err != nil {
+		return err
+	}
+
+	cfg := csvimport.Config{Columns: cols, Comma: sep, TimeLayout: *layout}
+
+	files := fs.Args()
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
+
+	var imported, failed int
+	for _, file := range files {
+		n, bad, err := importFile(j, file, stdin, cfg, user)
+		imported += n
+		failed += bad
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(stdout, "Импортировано записей: %d, с ошибками: %d\n", imported, failed)
+	return nil
+}
+
+func importFile(j *journal.Journal, file string, stdin io.Reader, cfg csvimport.Config, user profile.Profile) (int, int, error) {
+	r := stdin
+	if file != "-" {
+		f, err := os.Open(file)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	cr, err := csvimport.NewReader(r, cfg)
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", file, err)
+	}
+
+	var imported, failed int
+	for {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return imported, failed, nil
+		}
+		var rowErr *csvimport.RowError
+		if errors.As(err, &rowErr) {
+			log.Printf("%s: %v", file, rowErr)
+			failed++
+			continue
+		}
+		if err != nil {
+			return imported, failed, fmt.Errorf("%s: %w", file, err)
+		}
+
+		at := rec.Time
+		if at.IsZero() {
+			at = time.Now()
+		}
+
+		add := j.AddDay
+		if rec.Kind == journal.KindTraining {
+			add = j.AddTraining
+		}
+		if _, err := add(at, rec.Packet, user); err != nil {
+			var pathErr *os.PathError
+			if errors.As(err, &pathErr) {
+				return imported, failed, err
+			}
+			log.Printf("%s: строка %d: %v", file, rec.Row, err)
+			failed++
+			continue
+		}
+		imported++
+	}
+}
@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Yandex-Practicum/tracker/internal/batch"
+	"github.com/Yandex-Practicum/tracker/internal/heartrate"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// profileFlags — флаги с параметрами пользователя, общие для всех подкоманд.
+type profileFlags struct {
+	weight     float64
+	height     string
+	age        int
+	sex        string
+	stepLength float64
+	stride     string
+	model      string
+	maxHR      int
+	restingHR  int
+	hrZones    string
+	path       string
+}
+
+func addProfileFlags(fs *flag.FlagSet) *profileFlags {
+	p := &profileFlags{}
+	fs.Float64Var(&p.weight, "weight", 0, "вес пользователя в килограммах (в имперской системе — в фунтах)")
+	fs.StringVar(&p.height, "height", "", "рост пользователя в метрах (в имперской системе — 5'11\" или в дюймах)")
+	fs.IntVar(&p.age, "age", 0, "возраст пользователя в годах")
+	fs.StringVar(&p.sex, "sex", "", "пол пользователя: male или female")
+	fs.Float64Var(&p.stepLength, "step-length", 0, "откалиброванная длина шага в метрах (в имперской системе — в дюймах)")
+	fs.StringVar(&p.stride, "stride", "", "модель длины шага: fixed, height или calibrated")
+	fs.StringVar(&p.model, "model", "", "модель расчета калорий для тренировок: legacy или met")
+	fs.IntVar(&p.maxHR, "max-hr", 0, "максимальный пульс, уд/мин (по умолчанию 220 - возраст)")
+	fs.IntVar(&p.restingHR, "resting-hr", 0, "пульс в покое, уд/мин")
+	fs.StringVar(&p.hrZones, "hr-zones", "", "способ расчета пульсовых зон: max или reserve")
+	fs.StringVar(&p.path, "profile", "", "JSON-файл профиля")
+	return p
+}
+
+// set сообщает, задан ли профиль флагами -profile, -weight или -height.
+func (p *profileFlags) set() bool {
+	return p.path != "" || p.weight != 0 || p.height != ""
+}
+
+// load возвращает профиль пользователя. Значения флагов имеют приоритет
+// над файлом профиля. system — значение флага -units: в этой системе
+// задаются флаги, и она же становится системой единиц отчетов.
+func (p *profileFlags) load(system string) (profile.Profile, error) {
+	var user profile.Profile
+	if p.path != "" {
+		var err error
+		if user, err = profile.Load(p.path); err != nil {
+			return profile.Profile{}, err
+		}
+	}
+
+	if system != "" {
+		user.Units = units.System(system)
+	}
+	sys, err := units.Parse(string(user.Units))
+	if err != nil {
+		return profile.Profile{}, err
+	}
+
+	if p.weight != 0 {
+		user.Weight = sys.Weight(p.weight)
+	}
+	if p.height != "" {
+		if user.Height, err = sys.ParseLength(p.height); err != nil {
+			return profile.Profile{}, err
+		}
+	}
+	if p.age != 0 {
+		user.Age = p.age
+	}
+	if p.sex != "" {
+		user.Sex = profile.Sex(p.sex)
+	}
+	if p.stepLength != 0 {
+		user.StepLength = sys.Length(p.stepLength)
+	}
+	if p.stride != "" {
+		user.Stride = stride.Strategy(p.stride)
+	}
+	if p.model != "" {
+		user.Model = p.model
+	}
+	if p.maxHR != 0 {
+		user.MaxHeartRate = p.maxHR
+	}
+	if p.restingHR != 0 {
+		user.RestingHeartRate = p.restingHR
+	}
+	if p.hrZones != "" {
+		user.HeartRateZones = heartrate.Method(p.hrZones)
+	}
+
+	if user.Weight <= 0 || user.Height <= 0 {
+		return profile.Profile{}, errors.New("укажите вес и рост через -weight и -height или -profile")
+	}
+	if err := user.Validate(); err != nil {
+		return profile.Profile{}, err
+	}
+	if _, err := spentcalories.ParseModel(user.Model); err != nil {
+		return profile.Profile{}, err
+	}
+
+	return user, nil
+}
+
+// eachInput по очереди открывает входные файлы и передает их в fn.
+// Без файлов или для имени "-" используется stdin.
+func eachInput(paths []string, stdin io.Reader, fn func(path string, r io.Reader) error) error {
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	for _, path := range paths {
+		if err := openInput(path, stdin, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func openInput(path string, stdin io.Reader, fn func(path string, r io.Reader) error) error {
+	if path == "-" {
+		return fn(path, stdin)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return fn(path, f)
+}
+
+// readPackets построчно читает пакеты из файлов и передает каждую непустую
+// строку в handle. Чтение прекращается при первой ошибке handle.
+func readPackets(paths []string, stdin io.Reader, handle func(data string) error) error {
+	return eachInput(paths, stdin, func(path string, r io.Reader) error {
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" {
+				continue
+			}
+			if err := handle(line); err != nil {
+				return err
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("ошибка чтения %s: %w", path, err)
+		}
+
+		return nil
+	})
+}
+
+// processPackets потоково обрабатывает пакеты из файлов процессором p.
+func processPackets(paths []string, stdin io.Reader, stdout io.Writer, p *batch.Processor) error {
+	return eachInput(paths, stdin, func(path string, r io.Reader) error {
+		if _, err := p.Run(r, stdout); err != nil {
+			return fmt.Errorf("ошибка обработки %s: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// outputFlags — флаги вывода отчетов.
+type outputFlags struct {
+	format string
+	lang   string
+}
+
+func addOutputFlags(fs *flag.FlagSet) *outputFlags {
+	o := &outputFlags{}
+	fs.StringVar(&o.format, "format", string(report.FormatText), "формат вывода: text или json")
+	fs.StringVar(&o.lang, "lang", string(i18n.Default), "язык текстовых отчетов: ru, en")
+	return o
+}
+
+// addUnitsFlag добавляет флаг выбора системы единиц.
+func addUnitsFlag(fs *flag.FlagSet) *string {
+	return fs.String("units", "", "система единиц: metric или imperial (по умолчанию — из профиля или metric)")
+}
+
+// parse возвращает выбранные формат и локаль; system — система единиц
+// текстовых отчетов.
+func (o *outputFlags) parse(system units.System) (report.Format, i18n.Locale, error) {
+	format, err := report.ParseFormat(o.format)
+	if err != nil {
+		return "", i18n.Locale{}, err
+	}
+	lang, err := i18n.Parse(o.lang)
+	if err != nil {
+		return "", i18n.Locale{}, err
+	}
+
+	return format, i18n.Locale{Lang: lang, Units: system}, nil
+}
+
+// encoder создает Encoder с выбранными форматом и локалью.
+func (o *outputFlags) encoder(w io.Writer, system units.System) (*report.Encoder, error) {
+	format, loc, err := o.parse(system)
+	if err != nil {
+		return nil, err
+	}
+
+	enc := report.NewEncoder(w, format)
+	enc.SetLocale(loc)
+	return enc, nil
+}
+
+// processor создает batch.Processor с выбранными форматом и локалью.
+func (o *outputFlags) processor(handle batch.Handler, system units.System) (*batch.Processor, error) {
+	format, loc, err := o.parse(system)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batch.Processor{Handle: handle, Format: format, Locale: loc}, nil
+}
+
+// packetError добавляет к ошибке исходный пакет, если ошибка разбора
+// его еще не содержит.
+func packetError(data string, err error) error {
+	var pe *packet.ParseError
+	if errors.As(err, &pe) {
+		return err
+	}
+	return fmt.Errorf("пакет %q: %w", data, err)
+}
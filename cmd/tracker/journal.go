@@ -0,0 +1,266 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/goals"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// runJournal работает с журналом активности: "journal add" сохраняет
+// пакеты, "journal report" печатает дневные итоги, "journal summary" —
+// итоги по неделям или месяцам, "journal goals" — прогресс по дневным целям.
+func runJournal(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("укажите действие: journal add day|training, journal report, journal summary или journal goals")
+	}
+
+	switch args[0] {
+	case "add":
+		return runJournalAdd(args[1:], stdin)
+	case "report":
+		return runJournalReport(args[1:], stdout)
+	case "summary":
+		return runJournalSummary(args[1:], stdout)
+	case "goals":
+		return runJournalGoals(args[1:], stdout)
+	default:
+		return fmt.Errorf("неизвестное действие журнала %q", args[0])
+	}
+}
+
+func runJournalAdd(args []string, stdin io.Reader) error {
+	if len(args) == 0 {
+		return errors.New("укажите вид записи: day или training")
+	}
+	kind := journal.Kind(args[0])
+	if kind != journal.KindDay && kind != journal.KindTraining {
+		return fmt.Errorf("неизвестный вид записи %q", args[0])
+	}
+
+	fs := flag.NewFlagSet("journal add", flag.ContinueOnError)
+	path := fs.String("journal", defaultJournalPath(), "файл журнала")
+	at := fs.String("at", "", "время записи пакетов без метки времени и дата меток без даты, RFC 3339 (по умолчанию — текущее)")
+	profileArgs := addProfileFlags(fs)
+	system := addUnitsFlag(fs)
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	user, err := profileArgs.load(*system)
+	if err != nil {
+		return err
+	}
+
+	when := time.Now()
+	if *at != "" {
+		when, err = time.Parse(time.RFC3339, *at)
+		if err != nil {
+			return fmt.Errorf("неверное время записи: %w", err)
+		}
+	}
+
+	j, err := journal.Open(*path)
+	if err != nil {
+		return err
+	}
+
+	add := j.AddDay
+	if kind == journal.KindTraining {
+		add = j.AddTraining
+	}
+
+	return readPackets(fs.Args(), stdin, func(data string) error {
+		if _, err := add(when, data, user); err != nil {
+			var pathErr *os.PathError
+			if errors.As(err, &pathErr) {
+				return err
+			}
+			log.Printf("не сохранено: %v", packetError(data, err))
+		}
+		return nil
+	})
+}
+
+func runJournalReport(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("journal report", flag.ContinueOnError)
+	path := fs.String("journal", defaultJournalPath(), "файл журнала")
+	date := fs.String("date", "", "показать историю за день в формате 2006-01-02")
+	output := addOutputFlags(fs)
+	system := addUnitsFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sys, err := units.Parse(*system)
+	if err != nil {
+		return err
+	}
+
+	enc, err := output.encoder(stdout, sys)
+	if err != nil {
+		return err
+	}
+
+	j, err := journal.Open(*path)
+	if err != nil {
+		return err
+	}
+
+	entries, err := j.Entries()
+	if err != nil {
+		return err
+	}
+
+	if *date == "" {
+		for _, d := range journal.Daily(entries) {
+			if err := enc.Encode(d); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+	if err != nil {
+		return fmt.Errorf("неверная дата: %w", err)
+	}
+
+	total := journal.Day(entries, day)
+	if err := enc.Encode(total); err != nil {
+		return err
+	}
+	if output.format == string(report.FormatJSON) {
+		return nil
+	}
+	for _, e := range total.Entries {
+		fmt.Fprintf(stdout, "%s  %-8s  %s\n", e.Time.Format("15:04:05"), e.Kind, e.Packet)
+	}
+
+	return nil
+}
+
+func runJournalSummary(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("journal summary", flag.ContinueOnError)
+	path := fs.String("journal", defaultJournalPath(), "файл журнала")
+	periodName := fs.String("period", string(journal.Week), "период итогов: week или month")
+	output := addOutputFlags(fs)
+	system := addUnitsFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	period, err := journal.ParsePeriod(*periodName)
+	if err != nil {
+		return err
+	}
+
+	sys, err := units.Parse(*system)
+	if err != nil {
+		return err
+	}
+
+	enc, err := output.encoder(stdout, sys)
+	if err != nil {
+		return err
+	}
+
+	j, err := journal.Open(*path)
+	if err != nil {
+		return err
+	}
+
+	entries, err := j.Entries()
+	if err != nil {
+		return err
+	}
+
+	for _, s := range journal.Summaries(entries, period) {
+		if err := enc.Encode(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func runJournalGoals(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("journal goals", flag.ContinueOnError)
+	path := fs.String("journal", defaultJournalPath(), "файл журнала")
+	date := fs.String("date", "", "показать прогресс за день в формате 2006-01-02")
+	steps := fs.Int("steps", 0, "цель по шагам за день")
+	distance := fs.Float64("distance", 0, "цель по дистанции за день в километрах (в имперской системе — в милях)")
+	calories := fs.Float64("calories", 0, "цель по калориям за день, ккал")
+	output := addOutputFlags(fs)
+	system := addUnitsFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	sys, err := units.Parse(*system)
+	if err != nil {
+		return err
+	}
+
+	g := goals.Goals{Steps: *steps, Distance: sys.Kilometers(*distance), Calories: *calories}
+	if err := g.Validate(); err != nil {
+		return fmt.Errorf("%w: укажите -steps, -distance или -calories", err)
+	}
+
+	enc, err := output.encoder(stdout, sys)
+	if err != nil {
+		return err
+	}
+
+	j, err := journal.Open(*path)
+	if err != nil {
+		return err
+	}
+
+	days, err := j.Daily()
+	if err != nil {
+		return err
+	}
+	progress := goals.Track(days, g)
+
+	if *date != "" {
+		day, err := time.ParseInLocation("2006-01-02", *date, time.Local)
+		if err != nil {
+			return fmt.Errorf("неверная дата: %w", err)
+		}
+
+		p := goals.Progress{Date: day, Goals: g}
+		for _, dp := range progress {
+			if dp.Date.Format("2006-01-02") == *date {
+				p = dp
+			}
+		}
+		return enc.Encode(p)
+	}
+
+	for _, p := range progress {
+		if err := enc.Encode(p); err != nil {
+			return err
+		}
+	}
+
+	return enc.Encode(goals.CurrentStreaks(progress, time.Now()))
+}
+
+// defaultJournalPath возвращает путь к журналу по умолчанию: ~/.tracker/journal.jsonl.
+func defaultJournalPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "journal.jsonl"
+	}
+	return filepath.Join(home, ".tracker", "journal.jsonl")
+}
@@ -1,69 +1,72 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
-
-	"github.com/Yandex-Practicum/tracker/internal/daysteps"
-	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"os"
 )
 
-func main() {
-	weight := 84.6
-	height := 1.87
-
-	// дневная активность
-	input := []string{
-		"678,0h50m",
-		"792,1h14m",
-		"1078,1h30m",
-		"7830,2h40m",
-		",3456",
-		"12:40:00, 3456",
-		"something is wrong",
-	}
+// command — подкоманда утилиты tracker.
+type command struct {
+	name  string
+	usage string
+	run   func(args []string, stdin io.Reader, stdout io.Writer) error
+}
 
-	fmt.Println("Активность в течение дня")
+var commands = []command{
+	{name: "day", usage: "отчет о дневной активности по пакетам \"шаги,длительность\"", run: runDay},
+	{name: "training", usage: "отчет о тренировках по пакетам \"шаги,тип,длительность[,параметры]\"", run: runTraining},
+	{name: "journal", usage: "журнал активности: journal add day|training, journal report, journal summary, journal goals", run: runJournal},
+	{name: "import", usage: "импорт CSV-экспорта в журнал активности", run: runImport},
+	{name: "serve", usage: "HTTP API для расчетов дневной активности и тренировок", run: runServe},
+}
 
-	var (
-		dayActionsInfo string
-		dayActionsLog  []string
-	)
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("tracker: ")
 
-	for _, v := range input {
-		dayActionsInfo = daysteps.DayActionInfo(v, weight, height)
-		dayActionsLog = append(dayActionsLog, dayActionsInfo)
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		log.Fatal(err)
 	}
+}
 
-	for _, v := range dayActionsLog {
-		fmt.Println(v)
+// run выбирает подкоманду по первому аргументу и передает ей остальные.
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	if len(args) == 0 {
+		usage(os.Stderr)
+		return flag.ErrHelp
 	}
 
-	// тренировки
-	trainings := []string{
-		"3456,Ходьба,3h00m",
-		"something is wrong",
-		"678,Бег,0h5m",
-		"1078,Бег,0h10m",
-		",3456 Ходьба",
-		"7892,Ходьба,3h10m",
-		"15392,Бег,0h45m",
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			if err := loadActivities(); err != nil {
+				return err
+			}
+			return cmd.run(args[1:], stdin, stdout)
+		}
 	}
 
-	var trainingLog []string
-
-	for _, v := range trainings {
-		trainingInfo, err := spentcalories.TrainingInfo(v, weight, height)
-		if err != nil {
-			log.Printf("не получилось получить информацию о тренировке: %v", err)
-			continue
-		}
-		trainingLog = append(trainingLog, trainingInfo)
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stderr)
+		return flag.ErrHelp
 	}
 
-	fmt.Println("Журнал тренировок")
+	return fmt.Errorf("неизвестная команда %q", args[0])
+}
 
-	for _, v := range trainingLog {
-		fmt.Println(v)
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Использование: tracker <команда> [флаги] [файлы...]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Команды:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", cmd.name, cmd.usage)
 	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Пакеты читаются построчно из указанных файлов или из stdin (\"-\").")
 }
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/server"
+)
+
+// shutdownTimeout — время на завершение активных запросов при остановке сервера.
+const shutdownTimeout = 5 * time.Second
+
+// runServe запускает HTTP API. Профиль из флагов используется для запросов,
+// в которых профиль не указан; без флагов профиль обязателен в запросе.
+func runServe(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "адрес HTTP-сервера")
+	profileArgs := addProfileFlags(fs)
+	system := addUnitsFlag(fs)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	srv := &server.Server{}
+	if profileArgs.set() {
+		user, err := profileArgs.load(*system)
+		if err != nil {
+			return err
+		}
+		srv.Profile = &user
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           srv.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		log.Printf("сервер слушает %s", *addr)
+		errCh <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
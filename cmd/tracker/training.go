@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"log"
+
+	"github.com/Yandex-Practicum/tracker/internal/batch"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// runTraining выводит отчеты о тренировках.
+func runTraining(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("training", flag.ContinueOnError)
+	profileArgs := addProfileFlags(fs)
+	output := addOutputFlags(fs)
+	system := addUnitsFlag(fs)
+	pace := fs.Bool("pace", false, "добавить к отчетам о беге темп и сплиты")
+	zones := fs.Bool("zones", false, "добавить к отчетам время в пульсовых зонах")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	user, err := profileArgs.load(*system)
+	if err != nil {
+		return err
+	}
+
+	handle := batch.Trainings(user)
+	if *pace {
+		handle = withPace(user)
+	}
+	if *zones {
+		if handle, err = withZones(user, *pace); err != nil {
+			return err
+		}
+	}
+
+	p, err := output.processor(handle, user.Units)
+	if err != nil {
+		return err
+	}
+
+	p.OnError = func(err *batch.LineError) {
+		log.Printf("не получилось получить информацию о тренировке: %v", packetError(err.Data, err.Err))
+	}
+
+	return processPackets(fs.Args(), stdin, stdout, p)
+}
+
+// withPace возвращает обработчик тренировок, который для типов с темпом
+// (см. spentcalories.Activity.Pace) выводит spentcalories.PaceReport.
+func withPace(user profile.Profile) batch.Handler {
+	return func(data string) (report.Report, error) {
+		summary, err := spentcalories.CalculateFor(data, user)
+		if err != nil {
+			return nil, err
+		}
+		if summary.HasPace() {
+			return spentcalories.PaceReport{Summary: summary}, nil
+		}
+		return summary, nil
+	}
+}
+
+// withZones возвращает обработчик тренировок, который выводит
+// spentcalories.ZoneReport с зонами пользователя. С pace зоны добавляются
+// к spentcalories.PaceReport для типов с темпом.
+func withZones(user profile.Profile, pace bool) (batch.Handler, error) {
+	zones, err := user.Zones()
+	if err != nil {
+		return nil, err
+	}
+
+	return func(data string) (report.Report, error) {
+		summary, err := spentcalories.CalculateFor(data, user)
+		if err != nil {
+			return nil, err
+		}
+		r := spentcalories.ZoneReport{Summary: summary, Zones: zones}
+		if pace && summary.HasPace() {
+			r.Base = spentcalories.PaceReport{Summary: summary}
+		}
+		return r, nil
+	}, nil
+}
@@ -0,0 +1,126 @@
+// Package batch потоково обрабатывает пакеты данных: читает их построчно
+// из io.Reader и пишет отчеты в io.Writer, не накапливая ни входные
+// данные, ни результаты в памяти. Pool параллельно рассчитывает отчеты
+// по срезу пакетов разных пользователей.
+package batch
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// DefaultMaxLineSize — максимальная длина строки с пакетом по умолчанию.
+const DefaultMaxLineSize = 64 * 1024
+
+// ErrLineTooLong возвращается, если строка длиннее Processor.MaxLineSize.
+var ErrLineTooLong = errors.New("слишком длинная строка")
+
+// Handler рассчитывает отчет по одному пакету данных.
+type Handler func(data string) (report.Report, error)
+
+// Days возвращает Handler для пакетов дневной активности.
+func Days(p profile.Profile) Handler {
+	return func(data string) (report.Report, error) {
+		return daysteps.CalculateFor(data, p)
+	}
+}
+
+// Trainings возвращает Handler для пакетов тренировок.
+func Trainings(p profile.Profile) Handler {
+	return func(data string) (report.Report, error) {
+		return spentcalories.CalculateFor(data, p)
+	}
+}
+
+// LineError — ошибка обработки пакета в строке Line.
+type LineError struct {
+	Line int    // номер строки, начиная с 1.
+	Data string // пакет без начальных и конечных пробелов.
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("строка %d: %v", e.Line, e.Err)
+}
+
+func (e *LineError) Unwrap() error {
+	return e.Err
+}
+
+// Stats — итоги обработки.
+type Stats struct {
+	Processed int // пакеты, по которым выведен отчет.
+	Failed    int // пакеты с ошибками.
+}
+
+// Processor обрабатывает поток пакетов. Пустые строки пропускаются,
+// ошибки в отдельных пакетах передаются в OnError и не прерывают
+// обработку.
+type Processor struct {
+	Handle      Handler
+	Format      report.Format
+	Locale      i18n.Locale
+	OnError     func(err *LineError) // если nil, ошибочные пакеты только учитываются в Stats.
+	MaxLineSize int                  // если 0, используется DefaultMaxLineSize.
+}
+
+// Run читает пакеты из r и пишет отчеты в w. Вывод буферизуется и
+// сбрасывается в w перед возвратом. Ошибка возвращается только при сбое
+// чтения или записи; Stats при этом содержит итоги до сбоя.
+func (p *Processor) Run(r io.Reader, w io.Writer) (Stats, error) {
+	var stats Stats
+
+	maxSize := p.MaxLineSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxLineSize
+	}
+
+	out := bufio.NewWriter(w)
+	enc := report.NewEncoder(out, p.Format)
+	enc.SetLocale(p.Locale)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, min(maxSize, bufio.MaxScanTokenSize)), maxSize)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		data := strings.TrimSpace(scanner.Text())
+		if data == "" {
+			continue
+		}
+
+		rep, err := p.Handle(data)
+		if err != nil {
+			stats.Failed++
+			if p.OnError != nil {
+				p.OnError(&LineError{Line: line, Data: data, Err: err})
+			}
+			continue
+		}
+
+		if err := enc.Encode(rep); err != nil {
+			return stats, err
+		}
+		stats.Processed++
+	}
+
+	if err := scanner.Err(); err != nil {
+		if errors.Is(err, bufio.ErrTooLong) {
+			err = fmt.Errorf("строка %d: %w (больше %d байт)", line+1, ErrLineTooLong, maxSize)
+		}
+		out.Flush()
+		return stats, err
+	}
+
+	return stats, out.Flush()
+}
@@ -0,0 +1,94 @@
+package batch
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var user = profile.Profile{Weight: 84.6, Height: 1.87}
+
+type BatchTestSuite struct {
+	suite.Suite
+}
+
+func TestBatchSuite(t *testing.T) {
+	suite.Run(t, new(BatchTestSuite))
+}
+
+func (suite *BatchTestSuite) TestDays() {
+	var errs []*LineError
+	p := &Processor{
+		Handle:  Days(user),
+		OnError: func(err *LineError) { errs = append(errs, err) },
+	}
+
+	input := "678,0h50m\n\n  7830,2h40m  \n,3h00m\n"
+	var out bytes.Buffer
+	stats, err := p.Run(strings.NewReader(input), &out)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Stats{Processed: 2, Failed: 1}, stats)
+
+	first := daysteps.DayActionInfo("678,0h50m", user.Weight, user.Height)
+	second := daysteps.DayActionInfo("7830,2h40m", user.Weight, user.Height)
+	assert.Equal(suite.T(), first+"\n"+second+"\n", out.String())
+
+	require.Len(suite.T(), errs, 1)
+	assert.Equal(suite.T(), 4, errs[0].Line)
+	assert.Equal(suite.T(), ",3h00m", errs[0].Data)
+	assert.ErrorIs(suite.T(), errs[0], packet.ErrBadSteps)
+}
+
+func (suite *BatchTestSuite) TestTrainingsJSON() {
+	p := &Processor{Handle: Trainings(user), Format: report.FormatJSON}
+
+	var out bytes.Buffer
+	stats, err := p.Run(strings.NewReader("6000,Бег,1h00m\n6000,Плавание,1h00m\n4000,Ходьба,1h00m\n"), &out)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Stats{Processed: 2, Failed: 1}, stats)
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(suite.T(), lines, 2)
+	assert.Contains(suite.T(), lines[0], `"activity":"Бег"`)
+	assert.Contains(suite.T(), lines[1], `"activity":"Ходьба"`)
+
+	summary, err := spentcalories.Calculate("6000,Бег,1h00m", user.Weight, user.Height)
+	require.NoError(suite.T(), err)
+	data, err := summary.MarshalJSON()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), string(data), lines[0])
+}
+
+func (suite *BatchTestSuite) TestLineTooLong() {
+	p := &Processor{Handle: Days(user), MaxLineSize: 16}
+
+	var out bytes.Buffer
+	stats, err := p.Run(strings.NewReader("678,0h50m\n"+strings.Repeat("1", 32)+",1h\n"), &out)
+	assert.ErrorIs(suite.T(), err, ErrLineTooLong)
+	assert.Contains(suite.T(), err.Error(), "строка 2")
+	assert.Equal(suite.T(), Stats{Processed: 1}, stats)
+	assert.NotEmpty(suite.T(), out.String(), "отчеты до ошибки должны быть записаны")
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("запись невозможна")
+}
+
+func (suite *BatchTestSuite) TestWriteError() {
+	p := &Processor{Handle: Days(user)}
+
+	_, err := p.Run(strings.NewReader("678,0h50m\n"), failingWriter{})
+	assert.Error(suite.T(), err)
+}
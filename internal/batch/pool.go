@@ -0,0 +1,128 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// Job — пакет данных и профиль пользователя, для которого он считается.
+type Job struct {
+	Packet  string
+	Profile profile.Profile
+}
+
+// JobError — ошибка расчета задачи с номером Index во входном срезе.
+type JobError struct {
+	Index  int
+	Packet string
+	Err    error
+}
+
+func (e *JobError) Error() string {
+	return fmt.Sprintf("задача %d (пакет %q): %v", e.Index, e.Packet, e.Err)
+}
+
+func (e *JobError) Unwrap() error {
+	return e.Err
+}
+
+// Errors — ошибки отдельных задач, упорядоченные по Index.
+type Errors []*JobError
+
+func (e Errors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	return fmt.Sprintf("%v (и еще ошибок: %d)", e[0], len(e)-1)
+}
+
+// Unwrap позволяет искать ошибки отдельных задач через errors.Is и errors.As.
+func (e Errors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Pool параллельно рассчитывает отчеты по большому числу пакетов.
+// Результаты возвращаются в порядке задач; ошибки отдельных задач
+// не прерывают расчет остальных и собираются в Errors.
+type Pool struct {
+	Workers int // число обработчиков; если 0, используется runtime.GOMAXPROCS(0).
+}
+
+// Trainings рассчитывает тренировки. Для задач с ошибками в результате
+// остается нулевое значение.
+func (p Pool) Trainings(ctx context.Context, jobs []Job) ([]spentcalories.TrainingSummary, error) {
+	return run(ctx, p.workers(), jobs, func(j Job) (spentcalories.TrainingSummary, error) {
+		return spentcalories.CalculateFor(j.Packet, j.Profile)
+	})
+}
+
+// Days рассчитывает дневную активность аналогично Trainings.
+func (p Pool) Days(ctx context.Context, jobs []Job) ([]daysteps.DayAction, error) {
+	return run(ctx, p.workers(), jobs, func(j Job) (daysteps.DayAction, error) {
+		return daysteps.CalculateFor(j.Packet, j.Profile)
+	})
+}
+
+func (p Pool) workers() int {
+	if p.Workers > 0 {
+		return p.Workers
+	}
+	return runtime.GOMAXPROCS(0)
+}
+
+// run вызывает calc для каждой задачи в workers горутинах и складывает
+// результаты по индексам задач. При отмене ctx новые задачи не запускаются,
+// а возвращается ctx.Err() вместе с уже готовыми результатами.
+func run[T any](ctx context.Context, workers int, jobs []Job, calc func(Job) (T, error)) ([]T, error) {
+	results := make([]T, len(jobs))
+	errs := make([]error, len(jobs))
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(jobs)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i], errs[i] = calc(jobs[i])
+			}
+		}()
+	}
+
+dispatch:
+	for i := range jobs {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break dispatch
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+
+	var failed Errors
+	for i, err := range errs {
+		if err != nil {
+			failed = append(failed, &JobError{Index: i, Packet: jobs[i].Packet, Err: err})
+		}
+	}
+	if len(failed) > 0 {
+		return results, failed
+	}
+
+	return results, nil
+}
@@ -0,0 +1,89 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PoolTestSuite struct {
+	suite.Suite
+}
+
+func TestPoolSuite(t *testing.T) {
+	suite.Run(t, new(PoolTestSuite))
+}
+
+func (suite *PoolTestSuite) TestTrainingsOrdered() {
+	jobs := make([]Job, 200)
+	for i := range jobs {
+		jobs[i] = Job{
+			Packet:  fmt.Sprintf("%d,Бег,1h00m", 1000+i),
+			Profile: profile.Profile{Weight: 60 + float64(i%30), Height: 1.75},
+		}
+	}
+
+	summaries, err := Pool{Workers: 8}.Trainings(context.Background(), jobs)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), summaries, len(jobs))
+
+	for i, job := range jobs {
+		want, err := spentcalories.CalculateFor(job.Packet, job.Profile)
+		require.NoError(suite.T(), err)
+		assert.Equal(suite.T(), want, summaries[i], "задача %d", i)
+	}
+}
+
+func (suite *PoolTestSuite) TestErrorsAggregated() {
+	jobs := []Job{
+		{Packet: "6000,Бег,1h00m", Profile: user},
+		{Packet: ",Бег,1h00m", Profile: user},
+		{Packet: "6000,Ходьба,1h00m", Profile: user},
+		{Packet: "6000,Танцы,1h00m", Profile: user},
+	}
+
+	summaries, err := Pool{Workers: 3}.Trainings(context.Background(), jobs)
+	require.Error(suite.T(), err)
+	require.Len(suite.T(), summaries, len(jobs))
+	assert.Equal(suite.T(), "Бег", summaries[0].Activity)
+	assert.Equal(suite.T(), spentcalories.TrainingSummary{}, summaries[1])
+	assert.Equal(suite.T(), "Ходьба", summaries[2].Activity)
+
+	var failed Errors
+	require.True(suite.T(), errors.As(err, &failed))
+	require.Len(suite.T(), failed, 2)
+	assert.Equal(suite.T(), 1, failed[0].Index)
+	assert.Equal(suite.T(), 3, failed[1].Index)
+	assert.ErrorIs(suite.T(), err, packet.ErrBadSteps)
+	assert.ErrorIs(suite.T(), err, spentcalories.ErrUnknownTraining)
+}
+
+func (suite *PoolTestSuite) TestDays() {
+	jobs := []Job{
+		{Packet: "678,0h50m", Profile: user},
+		{Packet: "7830,2h40m", Profile: user},
+	}
+
+	actions, err := Pool{}.Days(context.Background(), jobs)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), actions, 2)
+	assert.Equal(suite.T(), 678, actions[0].Steps)
+	assert.Equal(suite.T(), 7830, actions[1].Steps)
+}
+
+func (suite *PoolTestSuite) TestCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	jobs := []Job{{Packet: "6000,Бег,1h00m", Profile: user}}
+	_, err := Pool{Workers: 1}.Trainings(ctx, jobs)
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+}
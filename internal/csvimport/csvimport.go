@@ -0,0 +1,294 @@
+// Package csvimport читает экспорт шагов и тренировок в формате CSV
+// и превращает строки в пакеты данных daysteps и spentcalories.
+package csvimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+)
+
+// Columns задает названия столбцов CSV для полей пакета. Обязательны
+// только Steps и Duration. Если столбец Activity не задан или пуст
+// в строке, строка считается пакетом дневной активности.
+type Columns struct {
+	Timestamp string   // время записи.
+	Steps     string   // количество шагов.
+	Activity  string   // тип тренировки.
+	Duration  string   // продолжительность.
+	Params    []string // дополнительные параметры тренировки по порядку.
+	HeartRate string   // средний пульс тренировки.
+}
+
+// ParseColumns разбирает описание столбцов вида
+// "time=Date,steps=Steps,activity=Type,duration=Duration,param=Pool,param=Laps,hr=HR".
+func ParseColumns(s string) (Columns, error) {
+	var c Columns
+	for _, pair := range strings.Split(s, ",") {
+		key, name, ok := strings.Cut(pair, "=")
+		key, name = strings.TrimSpace(key), strings.TrimSpace(name)
+		if !ok || name == "" {
+			return Columns{}, fmt.Errorf("неверное описание столбца %q: ожидается поле=столбец", pair)
+		}
+		switch key {
+		case "time":
+			c.Timestamp = name
+		case "steps":
+			c.Steps = name
+		case "activity":
+			c.Activity = name
+		case "duration":
+			c.Duration = name
+		case "param":
+			c.Params = append(c.Params, name)
+		case "hr":
+			c.HeartRate = name
+		default:
+			return Columns{}, fmt.Errorf("неизвестное поле %q: ожидается time, steps, activity, duration, param или hr", key)
+		}
+	}
+	return c, nil
+}
+
+// Config — настройки импорта.
+type Config struct {
+	Columns Columns
+	// Comma — разделитель полей, по умолчанию ','.
+	Comma rune
+	// TimeLayout — формат времени в столбце Timestamp, по умолчанию time.RFC3339.
+	TimeLayout string
+	// Location — часовой пояс для времени без явного смещения,
+	// по умолчанию time.Local.
+	Location *time.Location
+}
+
+// Record — строка CSV, преобразованная в пакет данных.
+type Record struct {
+	Row    int          // номер строки в файле, заголовок — строка 1.
+	Time   time.Time    // время записи; нулевое, если столбец не задан.
+	Kind   journal.Kind // вид пакета.
+	Packet string       // пакет в формате daysteps или spentcalories.
+}
+
+// RowError — ошибка в отдельной строке CSV. Импорт остальных строк
+// при этом продолжается.
+type RowError struct {
+	Row int
+	Err error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("строка %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Reader построчно читает CSV с заголовком.
+type Reader struct {
+	csv    *csv.Reader
+	cfg    Config
+	index  map[string]int
+	params []int
+	row    int
+}
+
+// NewReader читает заголовок CSV и проверяет, что в нем есть все
+// указанные столбцы.
+func NewReader(r io.Reader, cfg Config) (*Reader, error) {
+	if cfg.Columns.Steps == "" || cfg.Columns.Duration == "" {
+		return nil, errors.New("нужно указать столбцы steps и duration")
+	}
+	if cfg.TimeLayout == "" {
+		cfg.TimeLayout = time.RFC3339
+	}
+	if cfg.Location == nil {
+		cfg.Location = time.Local
+	}
+
+	cr := csv.NewReader(r)
+	if cfg.Comma != 0 {
+		cr.Comma = cfg.Comma
+	}
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать заголовок CSV: %w", err)
+	}
+
+	rd := &Reader{csv: cr, cfg: cfg, index: make(map[string]int), row: 1}
+	for i, name := range header {
+		rd.index[normalize(name)] = i
+	}
+
+	for _, name := range append([]string{cfg.Columns.Timestamp, cfg.Columns.Steps, cfg.Columns.Activity, cfg.Columns.Duration, cfg.Columns.HeartRate}, cfg.Columns.Params...) {
+		if name == "" {
+			continue
+		}
+		if _, ok := rd.index[normalize(name)]; !ok {
+			return nil, fmt.Errorf("в заголовке CSV нет столбца %q", name)
+		}
+	}
+	for _, name := range cfg.Columns.Params {
+		rd.params = append(rd.params, rd.index[normalize(name)])
+	}
+
+	return rd, nil
+}
+
+// normalize приводит название столбца к виду для сравнения без учета
+// регистра и пробелов по краям; также убирает BOM из первого столбца.
+func normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+}
+
+// Read возвращает следующую запись. В конце файла возвращается io.EOF.
+// Ошибка в данных строки возвращается как *RowError: после неё можно
+// продолжать чтение.
+func (r *Reader) Read() (Record, error) {
+	fields, err := r.csv.Read()
+	if err == io.EOF {
+		return Record{}, io.EOF
+	}
+	r.row++
+	if err != nil {
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return Record{}, &RowError{Row: r.row, Err: err}
+		}
+		return Record{}, err
+	}
+
+	rec, err := r.record(fields)
+	if err != nil {
+		return Record{}, &RowError{Row: r.row, Err: err}
+	}
+	return rec, nil
+}
+
+// ReadAll читает все записи. Ошибки в отдельных строках собираются
+// в список и не прерывают импорт; возвращаемая ошибка означает, что
+// файл не удалось дочитать.
+func (r *Reader) ReadAll() ([]Record, []*RowError, error) {
+	var (
+		records []Record
+		rowErrs []*RowError
+	)
+	for {
+		rec, err := r.Read()
+		if err == io.EOF {
+			return records, rowErrs, nil
+		}
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErrs = append(rowErrs, rowErr)
+			continue
+		}
+		if err != nil {
+			return records, rowErrs, err
+		}
+		records = append(records, rec)
+	}
+}
+
+func (r *Reader) field(fields []string, column string) string {
+	if column == "" {
+		return ""
+	}
+	i := r.index[normalize(column)]
+	if i >= len(fields) {
+		return ""
+	}
+	return strings.TrimSpace(fields[i])
+}
+
+func (r *Reader) record(fields []string) (Record, error) {
+	rec := Record{Row: r.row, Kind: journal.KindDay}
+
+	if ts := r.field(fields, r.cfg.Columns.Timestamp); ts != "" {
+		t, err := time.ParseInLocation(r.cfg.TimeLayout, ts, r.cfg.Location)
+		if err != nil {
+			return Record{}, fmt.Errorf("неверное время %q: %w", ts, err)
+		}
+		rec.Time = t
+	}
+
+	steps := r.field(fields, r.cfg.Columns.Steps)
+	duration, err := parseDuration(strings.Join(fields, ","), r.field(fields, r.cfg.Columns.Duration))
+	if err != nil {
+		return Record{}, err
+	}
+
+	parts := []string{steps, duration.String()}
+	if activity := r.field(fields, r.cfg.Columns.Activity); activity != "" {
+		rec.Kind = journal.KindTraining
+		parts = []string{steps, activity, duration.String()}
+		for _, i := range r.params {
+			if i < len(fields) && strings.TrimSpace(fields[i]) != "" {
+				parts = append(parts, strings.TrimSpace(fields[i]))
+			}
+		}
+		if hr := r.field(fields, r.cfg.Columns.HeartRate); hr != "" {
+			parts = append(parts, packet.OptionHeartRate+"="+hr)
+		}
+	}
+	rec.Packet = strings.Join(parts, ",")
+
+	if _, err := packet.Steps(strings.Join(fields, ","), steps); err != nil {
+		return Record{}, err
+	}
+
+	return rec, nil
+}
+
+// parseDuration понимает продолжительность в формате time.ParseDuration
+// ("1h30m"), а также в виде часов ЧЧ:ММ:СС, ММ:СС и числа секунд.
+// input — исходная строка CSV для сообщения об ошибке.
+func parseDuration(input, s string) (time.Duration, error) {
+	bad := func(err error) error {
+		return &packet.ParseError{Input: input, Field: packet.FieldDuration, Value: s, Err: err}
+	}
+
+	var d time.Duration
+	switch {
+	case s == "":
+		return 0, bad(packet.ErrBadDuration)
+	case strings.Contains(s, ":"):
+		parts := strings.Split(s, ":")
+		if len(parts) > 3 {
+			return 0, bad(packet.ErrBadDuration)
+		}
+		for _, p := range parts {
+			n, err := strconv.Atoi(p)
+			if err != nil || n < 0 {
+				return 0, bad(packet.ErrBadDuration)
+			}
+			d = d*60 + time.Duration(n)
+		}
+		d *= time.Second
+	default:
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			sec, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return 0, bad(fmt.Errorf("%w: %v", packet.ErrBadDuration, err))
+			}
+			d = time.Duration(sec * float64(time.Second))
+		}
+	}
+
+	if d <= 0 {
+		return 0, bad(packet.ErrNonPositiveDuration)
+	}
+	return d, nil
+}
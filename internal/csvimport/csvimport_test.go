@@ -0,0 +1,110 @@
+package csvimport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type CSVImportTestSuite struct {
+	suite.Suite
+}
+
+func TestCSVImportSuite(t *testing.T) {
+	suite.Run(t, new(CSVImportTestSuite))
+}
+
+func (suite *CSVImportTestSuite) TestParseColumns() {
+	c, err := ParseColumns("time=Date, steps=Steps,activity=Type,duration=Time,param=Pool,param=Laps")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Columns{
+		Timestamp: "Date",
+		Steps:     "Steps",
+		Activity:  "Type",
+		Duration:  "Time",
+		Params:    []string{"Pool", "Laps"},
+	}, c)
+
+	_, err = ParseColumns("steps")
+	assert.Error(suite.T(), err)
+	_, err = ParseColumns("speed=Speed")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CSVImportTestSuite) TestReadAll() {
+	data := "\ufeffDate;Steps;Type;Time;Pool;Laps;Note\n" +
+		"2026-10-17 08:00;6000;;1:00:00;;;утро\n" +
+		"2026-10-17 18:00;6000;Бег;45m;;;\"вечер; парк\"\n" +
+		"2026-10-17 19:00;1200;Плавание;60:00;25;40;\n" +
+		"2026-10-17 20:00;abc;;30m;;;\n" +
+		"вчера;100;;30m;;;\n" +
+		"2026-10-17 21:00;100;;0;;;\n"
+
+	r, err := NewReader(strings.NewReader(data), Config{
+		Columns: Columns{
+			Timestamp: "date",
+			Steps:     "Steps",
+			Activity:  "Type",
+			Duration:  "Time",
+			Params:    []string{"Pool", "Laps"},
+		},
+		Comma:      ';',
+		TimeLayout: "2006-01-02 15:04",
+		Location:   time.UTC,
+	})
+	require.NoError(suite.T(), err)
+
+	records, rowErrs, err := r.ReadAll()
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), []Record{
+		{Row: 2, Time: time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC), Kind: journal.KindDay, Packet: "6000,1h0m0s"},
+		{Row: 3, Time: time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC), Kind: journal.KindTraining, Packet: "6000,Бег,45m0s"},
+		{Row: 4, Time: time.Date(2026, 10, 17, 19, 0, 0, 0, time.UTC), Kind: journal.KindTraining, Packet: "1200,Плавание,1h0m0s,25,40"},
+	}, records)
+
+	require.Len(suite.T(), rowErrs, 3)
+	assert.Equal(suite.T(), 5, rowErrs[0].Row)
+	assert.ErrorIs(suite.T(), rowErrs[0], packet.ErrBadSteps)
+	assert.Equal(suite.T(), 6, rowErrs[1].Row)
+	assert.Equal(suite.T(), 7, rowErrs[2].Row)
+	assert.ErrorIs(suite.T(), rowErrs[2], packet.ErrNonPositiveDuration)
+}
+
+func (suite *CSVImportTestSuite) TestMissingColumn() {
+	_, err := NewReader(strings.NewReader("Steps,Time\n"), Config{
+		Columns: Columns{Steps: "Steps", Duration: "Duration"},
+	})
+	assert.ErrorContains(suite.T(), err, "Duration")
+
+	_, err = NewReader(strings.NewReader("Steps,Time\n"), Config{})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CSVImportTestSuite) TestHeartRateColumn() {
+	c, err := ParseColumns("steps=Steps,activity=Type,duration=Time,hr=HR")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "HR", c.HeartRate)
+
+	data := "Steps,Type,Time,HR\n" +
+		"6000,Бег,1h00m,150\n" +
+		"6000,Бег,1h00m,\n" +
+		"6000,,1h00m,120\n"
+
+	r, err := NewReader(strings.NewReader(data), Config{Columns: c})
+	require.NoError(suite.T(), err)
+
+	records, rowErrs, err := r.ReadAll()
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), rowErrs)
+	require.Len(suite.T(), records, 3)
+	assert.Equal(suite.T(), "6000,Бег,1h0m0s,hr=150", records[0].Packet)
+	assert.Equal(suite.T(), "6000,Бег,1h0m0s", records[1].Packet)
+	assert.Equal(suite.T(), "6000,1h0m0s", records[2].Packet)
+}
@@ -0,0 +1,77 @@
+package daysteps
+
+import (
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *DayStepsTestSuite) TestCalculate() {
+	got, err := Calculate("6000,1h00m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), 6000, got.Steps)
+	assert.Equal(suite.T(), time.Hour, got.Duration)
+	assert.InDelta(suite.T(), 3.9, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 177.19, got.Calories, 0.01)
+	assert.Equal(suite.T(), DayActionInfo("6000,1h00m", 75.0, 1.75), got.String())
+
+	_, err = Calculate("0,1h00m", 75.0, 1.75)
+	assert.ErrorIs(suite.T(), err, packet.ErrNonPositiveSteps)
+
+	_, err = Calculate("6000", 75.0, 1.75)
+	assert.ErrorIs(suite.T(), err, packet.ErrFieldCount)
+}
+
+func (suite *DayStepsTestSuite) TestCalculateFor() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75, StepLength: 0.8}
+
+	got, err := CalculateFor("6000,1h00m", p)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.8, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 75*4.8*0.5, got.Calories, 1e-9)
+
+	_, err = CalculateFor("6000,1h00m", profile.Profile{Weight: 75.0})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *DayStepsTestSuite) TestCalculateForMatchesTraining() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75, Stride: stride.HeightBased}
+
+	day, err := CalculateFor("6000,1h00m", p)
+	require.NoError(suite.T(), err)
+
+	training, err := spentcalories.CalculateFor("6000,Ходьба,1h00m", p)
+	require.NoError(suite.T(), err)
+
+	assert.InDelta(suite.T(), training.Distance, day.Distance, 1e-9)
+	assert.InDelta(suite.T(), training.Calories, day.Calories, 1e-9)
+}
+
+func (suite *DayStepsTestSuite) TestCalculateStamped() {
+	got, err := Calculate("v2,2026-10-17T12:40:00Z,6000,1h00m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 6000, got.Steps)
+	assert.Equal(suite.T(), time.Date(2026, 10, 17, 12, 40, 0, 0, time.UTC), got.Stamp.Time)
+
+	legacy, err := Calculate("6000,1h00m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), legacy.String(), got.String())
+
+	data, err := got.MarshalJSON()
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"time":"2026-10-17T12:40:00Z"`)
+
+	_, err = Calculate("v2,12:40,6000,1h00m,extra", 75.0, 1.75)
+	assert.ErrorIs(suite.T(), err, packet.ErrFieldCount)
+
+	_, err = Calculate("v2,12:40,abc,1h00m", 75.0, 1.75)
+	var pe *packet.ParseError
+	require.ErrorAs(suite.T(), err, &pe)
+	assert.Equal(suite.T(), "v2,12:40,abc,1h00m", pe.Input)
+}
@@ -1,20 +1,112 @@
 package daysteps
 
 import (
+	"log"
 	"time"
-)
 
-const (
-	// Длина одного шага в метрах
-	stepLength = 0.65
-	// Количество метров в одном километре
-	mInKm = 1000
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
 )
 
+// parsePackage разбирает пакет данных вида "678,0h50m" и возвращает
+// количество шагов и продолжительность прогулки. Ошибки возвращаются
+// в виде *packet.ParseError.
 func parsePackage(data string) (int, time.Duration, error) {
-	// TODO: реализовать функцию
+	parts, err := packet.Split(data, 2, 2)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	steps, err := packet.Steps(data, parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	duration, err := packet.Duration(data, parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return steps, duration, nil
+}
+
+// DayAction — результат расчета одного пакета дневной активности.
+type DayAction struct {
+	Steps    int           // количество шагов.
+	Duration time.Duration // продолжительность прогулки.
+	Distance float64       // дистанция в километрах.
+	Calories float64       // потраченные калории, ккал.
+	Stamp    packet.Stamp  // метка времени пакета, пустая для пакетов версии 1.
+}
+
+// String возвращает отчет о дневной активности в текстовом виде на языке
+// по умолчанию и в метрических единицах.
+func (a DayAction) String() string {
+	return a.Format(i18n.Locale{})
+}
+
+// Format возвращает отчет о дневной активности на языке и в единицах локали.
+func (a DayAction) Format(loc i18n.Locale) string {
+	dist, unit := loc.Distance(a.Distance)
+	return loc.Sprintf(i18n.MsgDayReport, a.Steps, dist, unit, a.Calories)
 }
 
+// Calculate разбирает пакет данных и рассчитывает по нему дневную активность.
+func Calculate(data string, weight, height float64) (DayAction, error) {
+	return CalculateFor(data, profile.Profile{Weight: weight, Height: height})
+}
+
+// CalculateFor рассчитывает дневную активность для профиля пользователя.
+// Пакет может содержать заголовок с меткой времени (см. packet.Unstamp).
+// По умолчанию дистанция считается по фиксированной длине шага
+// (stride.Fixed); модель из профиля применяется и к дистанции,
+// и к расчету калорий.
+func CalculateFor(data string, p profile.Profile) (DayAction, error) {
+	if err := p.Validate(); err != nil {
+		return DayAction{}, err
+	}
+
+	_, stamp, body, err := packet.Unstamp(data)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	steps, duration, err := parsePackage(body)
+	if err != nil {
+		return DayAction{}, packet.WithInput(err, data)
+	}
+
+	calories, err := spentcalories.WalkingSpentCaloriesFor(steps, duration, p)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	length, err := p.StrideLength(stride.Fixed)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	return DayAction{
+		Steps:    steps,
+		Duration: duration,
+		Distance: stride.Distance(steps, length),
+		Calories: calories,
+		Stamp:    stamp,
+	}, nil
+}
+
+// DayActionInfo возвращает информацию о дневной активности: количество шагов,
+// пройденную дистанцию и потраченные калории. При ошибке в данных она
+// записывается в лог, а функция возвращает пустую строку.
 func DayActionInfo(data string, weight, height float64) string {
-	// TODO: реализовать функцию
+	action, err := Calculate(data, weight, height)
+	if err != nil {
+		log.Println(err)
+		return ""
+	}
+
+	return action.String()
 }
@@ -0,0 +1,26 @@
+package daysteps
+
+import "encoding/json"
+
+// dayActionJSON — JSON-представление DayAction. Названия полей и единицы
+// измерения являются частью формата и не должны меняться.
+type dayActionJSON struct {
+	Steps    int     `json:"steps"`
+	Duration float64 `json:"duration_s"`
+	Distance float64 `json:"distance_km"`
+	Calories float64 `json:"calories_kcal"`
+	Time     string  `json:"time,omitempty"`
+}
+
+// MarshalJSON кодирует отчет о дневной активности в JSON.
+// Продолжительность передается в секундах, метка времени пакета —
+// в формате RFC 3339 или "15:04:05" для метки без даты.
+func (a DayAction) MarshalJSON() ([]byte, error) {
+	return json.Marshal(dayActionJSON{
+		Steps:    a.Steps,
+		Duration: a.Duration.Seconds(),
+		Distance: a.Distance,
+		Calories: a.Calories,
+		Time:     a.Stamp.String(),
+	})
+}
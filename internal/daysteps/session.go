@@ -0,0 +1,178 @@
+package daysteps
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+)
+
+// Ошибки показаний шагомера.
+var (
+	ErrOutOfOrder = errors.New("показание раньше предыдущего")
+	ErrOtherDay   = errors.New("показание относится к другому дню")
+	ErrNoReadings = errors.New("нет показаний шагомера")
+)
+
+// Reading — показание шагомера: количество шагов с полуночи на момент Time.
+type Reading struct {
+	Time  time.Time
+	Total int
+}
+
+// ParseReading разбирает показание шагомера вида "12:40:00,3456":
+// метку времени (см. packet.ParseStamp) и накопленное количество шагов.
+// Пробелы вокруг полей допускаются. Время суток относится ко дню day.
+func ParseReading(data string, day time.Time) (Reading, error) {
+	parts, err := packet.Split(data, 2, 2)
+	if err != nil {
+		return Reading{}, err
+	}
+
+	value := strings.TrimSpace(parts[0])
+	stamp, err := packet.ParseStamp(value)
+	if err == nil && stamp.IsZero() {
+		err = fmt.Errorf("%w: метка времени обязательна", packet.ErrBadTimestamp)
+	}
+	if err != nil {
+		return Reading{}, &packet.ParseError{Input: data, Field: packet.FieldTime, Value: value, Err: err}
+	}
+
+	value = strings.TrimSpace(parts[1])
+	total, err := strconv.Atoi(value)
+	if err != nil {
+		return Reading{}, &packet.ParseError{Input: data, Field: packet.FieldSteps, Value: value, Err: fmt.Errorf("%w: %v", packet.ErrBadSteps, err)}
+	}
+	if total < 0 {
+		return Reading{}, &packet.ParseError{Input: data, Field: packet.FieldSteps, Value: value, Err: fmt.Errorf("%w: значение не может быть отрицательным", packet.ErrBadSteps)}
+	}
+
+	return Reading{Time: stamp.On(day), Total: total}, nil
+}
+
+// Delta — шаги между двумя показаниями шагомера.
+type Delta struct {
+	Time  time.Time // время показания.
+	Steps int       // шаги с предыдущего показания.
+	// Reset — показание меньше предыдущего: счетчик шагомера был сброшен,
+	// и Steps — шаги с момента сброса.
+	Reset bool
+}
+
+// Session собирает дневную активность по накопленным показаниям шагомера,
+// которые приходят в порядке времени. Показания из прошлого отклоняются
+// с ErrOutOfOrder, уменьшение счетчика считается его сбросом.
+type Session struct {
+	profile  profile.Profile
+	day      time.Time // полночь дня сессии.
+	last     Reading
+	started  bool
+	steps    int
+	resets   int
+	rejected int
+}
+
+// NewSession создает сессию для дня, в который попадает day. Время суток
+// в показаниях без даты относится к этому дню.
+func NewSession(day time.Time, p profile.Profile) (*Session, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	y, m, d := day.Date()
+	return &Session{profile: p, day: time.Date(y, m, d, 0, 0, 0, 0, day.Location())}, nil
+}
+
+// Add разбирает показание шагомера и учитывает его в сессии.
+func (s *Session) Add(data string) (Delta, error) {
+	r, err := ParseReading(data, s.day)
+	if err != nil {
+		s.rejected++
+		return Delta{}, err
+	}
+	return s.AddReading(r)
+}
+
+// AddReading учитывает показание шагомера и возвращает шаги с предыдущего
+// показания. Первое показание дня целиком считается шагами с полуночи.
+// Отклоненные показания не меняют сессию.
+func (s *Session) AddReading(r Reading) (Delta, error) {
+	if r.Time.Before(s.day) || !r.Time.Before(s.day.AddDate(0, 0, 1)) {
+		s.rejected++
+		return Delta{}, fmt.Errorf("%w: %s, сессия за %s", ErrOtherDay, r.Time.Format(time.RFC3339), s.day.Format("2006-01-02"))
+	}
+	if s.started && r.Time.Before(s.last.Time) {
+		s.rejected++
+		return Delta{}, fmt.Errorf("%w: %s после %s", ErrOutOfOrder, r.Time.Format("15:04:05"), s.last.Time.Format("15:04:05"))
+	}
+
+	delta := Delta{Time: r.Time, Steps: r.Total}
+	if s.started {
+		if r.Total >= s.last.Total {
+			delta.Steps = r.Total - s.last.Total
+		} else {
+			delta.Reset = true
+			s.resets++
+		}
+	}
+
+	s.steps += delta.Steps
+	s.last = r
+	s.started = true
+
+	return delta, nil
+}
+
+// Steps возвращает шаги за день с учетом сбросов счетчика.
+func (s *Session) Steps() int {
+	return s.steps
+}
+
+// Resets возвращает количество обнаруженных сбросов счетчика.
+func (s *Session) Resets() int {
+	return s.resets
+}
+
+// Rejected возвращает количество отклоненных показаний.
+func (s *Session) Rejected() int {
+	return s.rejected
+}
+
+// Total рассчитывает дневную активность по всем показаниям. Продолжительность
+// считается от полуночи до последнего показания, метка времени итога —
+// время последнего показания.
+func (s *Session) Total() (DayAction, error) {
+	if !s.started {
+		return DayAction{}, ErrNoReadings
+	}
+
+	duration := s.last.Time.Sub(s.day)
+	action := DayAction{
+		Steps:    s.steps,
+		Duration: duration,
+		Stamp:    packet.Stamp{Time: s.last.Time},
+	}
+	if s.steps == 0 || duration <= 0 {
+		return action, nil
+	}
+
+	calories, err := spentcalories.WalkingSpentCaloriesFor(s.steps, duration, s.profile)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	length, err := s.profile.StrideLength(stride.Fixed)
+	if err != nil {
+		return DayAction{}, err
+	}
+
+	action.Distance = stride.Distance(s.steps, length)
+	action.Calories = calories
+	return action, nil
+}
@@ -0,0 +1,82 @@
+package daysteps
+
+import (
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sessionDay = time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)
+
+func (suite *DayStepsTestSuite) TestParseReading() {
+	r, err := ParseReading("12:40:00, 3456", sessionDay)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Reading{Time: time.Date(2026, 10, 17, 12, 40, 0, 0, time.UTC), Total: 3456}, r)
+
+	r, err = ParseReading("2026-10-17T08:00:00Z,0", sessionDay)
+	require.NoError(suite.T(), err)
+	assert.Zero(suite.T(), r.Total)
+
+	_, err = ParseReading(",3456", sessionDay)
+	assert.ErrorIs(suite.T(), err, packet.ErrBadTimestamp)
+
+	_, err = ParseReading("12:40,-5", sessionDay)
+	assert.ErrorIs(suite.T(), err, packet.ErrBadSteps)
+
+	_, err = ParseReading("something is wrong", sessionDay)
+	assert.ErrorIs(suite.T(), err, packet.ErrFieldCount)
+}
+
+func (suite *DayStepsTestSuite) TestSession() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75}
+	s, err := NewSession(sessionDay.Add(15*time.Hour), p)
+	require.NoError(suite.T(), err)
+
+	_, err = s.Total()
+	assert.ErrorIs(suite.T(), err, ErrNoReadings)
+
+	deltas := []struct {
+		input     string
+		wantSteps int
+		wantReset bool
+		wantErr   error
+	}{
+		{input: "08:00,1200", wantSteps: 1200},
+		{input: "09:00,3000", wantSteps: 1800},
+		{input: "08:30,2500", wantErr: ErrOutOfOrder},
+		{input: "09:00,3000", wantSteps: 0},
+		{input: "10:00,400", wantSteps: 400, wantReset: true},
+		{input: "2026-10-18T00:10:00Z,500", wantErr: ErrOtherDay},
+		{input: "12:00,2000", wantSteps: 1600},
+		{input: "12:00:00; 2000", wantErr: packet.ErrFieldCount},
+	}
+
+	for _, tt := range deltas {
+		d, err := s.Add(tt.input)
+		if tt.wantErr != nil {
+			assert.ErrorIs(suite.T(), err, tt.wantErr, tt.input)
+			continue
+		}
+		require.NoError(suite.T(), err, tt.input)
+		assert.Equal(suite.T(), tt.wantSteps, d.Steps, tt.input)
+		assert.Equal(suite.T(), tt.wantReset, d.Reset, tt.input)
+	}
+
+	assert.Equal(suite.T(), 5000, s.Steps())
+	assert.Equal(suite.T(), 1, s.Resets())
+	assert.Equal(suite.T(), 3, s.Rejected())
+
+	total, err := s.Total()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 5000, total.Steps)
+	assert.Equal(suite.T(), 12*time.Hour, total.Duration)
+	assert.Equal(suite.T(), time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC), total.Stamp.Time)
+
+	want, err := CalculateFor("5000,12h", p)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), want.Distance, total.Distance, 1e-9)
+	assert.InDelta(suite.T(), want.Calories, total.Calories, 1e-9)
+}
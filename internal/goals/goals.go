@@ -0,0 +1,238 @@
+// Package goals считает прогресс по дневным целям (шаги, дистанция,
+// калории) и серии дней, в которые цели выполнены, по итогам журнала.
+package goals
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+)
+
+// dateLayout — формат даты в отчетах.
+const dateLayout = "2006-01-02"
+
+// Goals — дневные цели. Нулевое значение цели означает, что она не задана.
+// День считается выполненным, если достигнуты все заданные цели.
+type Goals struct {
+	Steps    int     `json:"steps,omitempty"`         // шаги.
+	Distance float64 `json:"distance_km,omitempty"`   // дистанция в километрах.
+	Calories float64 `json:"calories_kcal,omitempty"` // калории, ккал.
+}
+
+// Validate проверяет, что задана хотя бы одна цель и все цели неотрицательны.
+func (g Goals) Validate() error {
+	if g.Steps < 0 || g.Distance < 0 || g.Calories < 0 {
+		return errors.New("цели не могут быть отрицательными")
+	}
+	if g.Steps == 0 && g.Distance == 0 && g.Calories == 0 {
+		return errors.New("не задано ни одной цели")
+	}
+	return nil
+}
+
+// Progress — прогресс по целям за один день.
+type Progress struct {
+	Date     time.Time // полночь дня.
+	Goals    Goals
+	Steps    int     // шаги за день.
+	Distance float64 // дистанция за день в километрах.
+	Calories float64 // калории за день, ккал.
+	// Streak — количество дней подряд до этого дня включительно,
+	// в которые цели выполнены; 0, если цели дня не выполнены.
+	Streak int
+}
+
+// StepsPercent возвращает процент выполнения цели по шагам.
+func (p Progress) StepsPercent() float64 {
+	return percent(float64(p.Steps), float64(p.Goals.Steps))
+}
+
+// DistancePercent возвращает процент выполнения цели по дистанции.
+func (p Progress) DistancePercent() float64 {
+	return percent(p.Distance, p.Goals.Distance)
+}
+
+// CaloriesPercent возвращает процент выполнения цели по калориям.
+func (p Progress) CaloriesPercent() float64 {
+	return percent(p.Calories, p.Goals.Calories)
+}
+
+// RemainingSteps возвращает, сколько шагов осталось до цели.
+func (p Progress) RemainingSteps() int {
+	return max(p.Goals.Steps-p.Steps, 0)
+}
+
+// Met сообщает, выполнены ли все заданные цели дня.
+func (p Progress) Met() bool {
+	return p.Steps >= p.Goals.Steps && p.Distance >= p.Goals.Distance && p.Calories >= p.Goals.Calories
+}
+
+func percent(v, goal float64) float64 {
+	if goal <= 0 {
+		return 0
+	}
+	return v / goal * 100
+}
+
+// String возвращает прогресс дня в текстовом виде на языке по умолчанию
+// и в метрических единицах.
+func (p Progress) String() string {
+	return p.Format(i18n.Locale{})
+}
+
+// Format возвращает прогресс дня на языке и в единицах локали. Выводятся
+// только заданные цели.
+func (p Progress) Format(loc i18n.Locale) string {
+	var b strings.Builder
+	b.WriteString(loc.Sprintf(i18n.MsgGoalDate, p.Date.Format(dateLayout)))
+
+	if p.Goals.Steps > 0 {
+		b.WriteString(loc.Sprintf(i18n.MsgGoalSteps, p.Steps, p.Goals.Steps, p.StepsPercent(), p.RemainingSteps()))
+	}
+	if p.Goals.Distance > 0 {
+		dist, unit := loc.Distance(p.Distance)
+		goal, _ := loc.Distance(p.Goals.Distance)
+		b.WriteString(loc.Sprintf(i18n.MsgGoalDistance, dist, goal, unit, p.DistancePercent()))
+	}
+	if p.Goals.Calories > 0 {
+		b.WriteString(loc.Sprintf(i18n.MsgGoalCalories, p.Calories, p.Goals.Calories, p.CaloriesPercent()))
+	}
+
+	if p.Met() {
+		b.WriteString(loc.Sprintf(i18n.MsgGoalMet, p.Streak))
+	} else {
+		b.WriteString(loc.Sprintf(i18n.MsgGoalNotMet))
+	}
+
+	return b.String()
+}
+
+// progressJSON — JSON-представление Progress в отчетах.
+type progressJSON struct {
+	Date     string  `json:"date"`
+	Goals    Goals   `json:"goals"`
+	Steps    int     `json:"steps"`
+	Distance float64 `json:"distance_km"`
+	Calories float64 `json:"calories_kcal"`
+	// Проценты выводятся только для заданных целей, в том числе нулевые.
+	StepsPercent    *float64 `json:"steps_percent,omitempty"`
+	DistancePercent *float64 `json:"distance_percent,omitempty"`
+	CaloriesPercent *float64 `json:"calories_percent,omitempty"`
+	RemainingSteps  int      `json:"remaining_steps"`
+	Met             bool     `json:"met"`
+	Streak          int      `json:"streak"`
+}
+
+// MarshalJSON кодирует прогресс дня в JSON.
+func (p Progress) MarshalJSON() ([]byte, error) {
+	pj := progressJSON{
+		Date:           p.Date.Format(dateLayout),
+		Goals:          p.Goals,
+		Steps:          p.Steps,
+		Distance:       p.Distance,
+		Calories:       p.Calories,
+		RemainingSteps: p.RemainingSteps(),
+		Met:            p.Met(),
+		Streak:         p.Streak,
+	}
+	if p.Goals.Steps > 0 {
+		pj.StepsPercent = ptr(p.StepsPercent())
+	}
+	if p.Goals.Distance > 0 {
+		pj.DistancePercent = ptr(p.DistancePercent())
+	}
+	if p.Goals.Calories > 0 {
+		pj.CaloriesPercent = ptr(p.CaloriesPercent())
+	}
+	return json.Marshal(pj)
+}
+
+func ptr(v float64) *float64 {
+	return &v
+}
+
+// Track считает прогресс по целям для каждого дня от первого до последнего
+// дня в days. Дни без записей входят в результат с нулевыми итогами
+// и прерывают серию. В итоги дня входят и пакеты дневной активности,
+// и тренировки.
+func Track(days []journal.DayTotal, g Goals) []Progress {
+	if len(days) == 0 {
+		return nil
+	}
+
+	var progress []Progress
+	streak := 0
+	date := days[0].Date
+	for _, d := range days {
+		for date.Before(d.Date) {
+			progress = append(progress, Progress{Date: date, Goals: g})
+			streak = 0
+			date = date.AddDate(0, 0, 1)
+		}
+
+		p := Progress{Date: d.Date, Goals: g, Steps: d.Steps, Distance: d.Distance, Calories: d.Calories}
+		if p.Met() {
+			streak++
+			p.Streak = streak
+		} else {
+			streak = 0
+		}
+		progress = append(progress, p)
+		date = d.Date.AddDate(0, 0, 1)
+	}
+
+	return progress
+}
+
+// Streaks — серии дней с выполненными целями.
+type Streaks struct {
+	Current int // текущая серия.
+	Longest int // самая длинная серия.
+}
+
+// CurrentStreaks возвращает серии по результату Track. Текущая серия
+// сохраняется, если последний выполненный день — сегодня или вчера:
+// сегодняшний день еще не закончился.
+func CurrentStreaks(progress []Progress, today time.Time) Streaks {
+	var s Streaks
+	for _, p := range progress {
+		s.Longest = max(s.Longest, p.Streak)
+	}
+
+	y, m, d := today.Date()
+	midnight := time.Date(y, m, d, 0, 0, 0, 0, today.Location())
+	for i := len(progress) - 1; i >= 0; i-- {
+		p := progress[i]
+		if p.Date.Before(midnight.AddDate(0, 0, -1)) {
+			break
+		}
+		if p.Streak > 0 && !p.Date.After(midnight) {
+			s.Current = p.Streak
+			break
+		}
+	}
+
+	return s
+}
+
+// String возвращает серии в текстовом виде на языке по умолчанию.
+func (s Streaks) String() string {
+	return s.Format(i18n.Locale{})
+}
+
+// Format возвращает серии на языке локали.
+func (s Streaks) Format(loc i18n.Locale) string {
+	return loc.Sprintf(i18n.MsgGoalStreaks, s.Current, s.Longest)
+}
+
+// MarshalJSON кодирует серии в JSON.
+func (s Streaks) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Current int `json:"current_streak"`
+		Longest int `json:"longest_streak"`
+	}{s.Current, s.Longest})
+}
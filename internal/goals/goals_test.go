@@ -0,0 +1,120 @@
+package goals
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/journal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func day(d int) time.Time {
+	return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC)
+}
+
+type GoalsTestSuite struct {
+	suite.Suite
+}
+
+func TestGoalsSuite(t *testing.T) {
+	suite.Run(t, new(GoalsTestSuite))
+}
+
+func (suite *GoalsTestSuite) TestValidate() {
+	assert.NoError(suite.T(), Goals{Steps: 10000}.Validate())
+	assert.Error(suite.T(), Goals{}.Validate())
+	assert.Error(suite.T(), Goals{Steps: 10000, Calories: -1}.Validate())
+}
+
+func (suite *GoalsTestSuite) TestProgress() {
+	p := Progress{Goals: Goals{Steps: 10000, Distance: 8}, Steps: 6000, Distance: 4}
+	assert.InDelta(suite.T(), 60, p.StepsPercent(), 1e-9)
+	assert.InDelta(suite.T(), 50, p.DistancePercent(), 1e-9)
+	assert.Zero(suite.T(), p.CaloriesPercent())
+	assert.Equal(suite.T(), 4000, p.RemainingSteps())
+	assert.False(suite.T(), p.Met())
+
+	p.Steps, p.Distance = 12000, 8
+	assert.Zero(suite.T(), p.RemainingSteps())
+	assert.True(suite.T(), p.Met())
+}
+
+func (suite *GoalsTestSuite) TestTrack() {
+	days := []journal.DayTotal{
+		{Date: day(10), Steps: 11000},
+		{Date: day(11), Steps: 12000},
+		{Date: day(12), Steps: 3000},
+		{Date: day(13), Steps: 10000},
+		// 14 октября записей нет.
+		{Date: day(15), Steps: 10500},
+		{Date: day(16), Steps: 15000},
+	}
+
+	progress := Track(days, Goals{Steps: 10000})
+	require.Len(suite.T(), progress, 7)
+
+	streaks := make([]int, len(progress))
+	for i, p := range progress {
+		streaks[i] = p.Streak
+	}
+	assert.Equal(suite.T(), []int{1, 2, 0, 1, 0, 1, 2}, streaks)
+	assert.Equal(suite.T(), day(14), progress[4].Date)
+	assert.Equal(suite.T(), 10000, progress[4].RemainingSteps())
+
+	assert.Equal(suite.T(), Streaks{Current: 2, Longest: 2}, CurrentStreaks(progress, day(16).Add(20*time.Hour)))
+	assert.Equal(suite.T(), Streaks{Current: 2, Longest: 2}, CurrentStreaks(progress, day(17)))
+	assert.Equal(suite.T(), Streaks{Current: 0, Longest: 2}, CurrentStreaks(progress, day(18)))
+
+	assert.Nil(suite.T(), Track(nil, Goals{Steps: 1}))
+}
+
+func (suite *GoalsTestSuite) TestFormat() {
+	p := Progress{Date: day(17), Goals: Goals{Steps: 10000, Calories: 300}, Steps: 6000, Calories: 150}
+	assert.Equal(suite.T(), "Дата: 2026-10-17\n"+
+		"Шаги: 6000 из 10000 (60%), осталось 4000.\n"+
+		"Калории: 150.00 из 300.00 ккал (50%).\n"+
+		"Цель дня не выполнена.\n", p.String())
+
+	p = Progress{Date: day(17), Goals: Goals{Distance: 8.04672}, Distance: 9.656064, Streak: 3}
+	assert.Equal(suite.T(), "Date: 2026-10-17\n"+
+		"Distance: 6.00 of 5.00 mi (120%).\n"+
+		"Daily goal met. Streak: 3 days.\n", p.Format(i18n.Locale{Lang: i18n.English, Units: "imperial"}))
+
+	assert.Equal(suite.T(), "Текущая серия: 2 дн. Лучшая серия: 5 дн.\n", Streaks{Current: 2, Longest: 5}.String())
+}
+
+func (suite *GoalsTestSuite) TestJSON() {
+	p := Progress{Date: day(17), Goals: Goals{Steps: 10000}, Steps: 12000, Streak: 1}
+	data, err := json.Marshal(p)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"date": "2026-10-17", "goals": {"steps": 10000}, "steps": 12000,
+		"distance_km": 0, "calories_kcal": 0, "steps_percent": 120, "remaining_steps": 0,
+		"met": true, "streak": 1}`, string(data))
+
+	// Проценты заданных целей выводятся и при нулевом прогрессе.
+	p = Progress{Date: day(18), Goals: Goals{Steps: 10000, Calories: 300}}
+	data, err = json.Marshal(p)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{"date": "2026-10-18", "goals": {"steps": 10000, "calories_kcal": 300}, "steps": 0,
+		"distance_km": 0, "calories_kcal": 0, "steps_percent": 0, "calories_percent": 0, "remaining_steps": 10000,
+		"met": false, "streak": 0}`, string(data))
+}
+
+func (suite *GoalsTestSuite) TestTrackNonStepActivities() {
+	entries := []journal.Entry{
+		{Time: day(10).Add(8 * time.Hour), Kind: journal.KindTraining, Activity: "Бег", Steps: 6000, Distance: 4.7},
+		{Time: day(10).Add(18 * time.Hour), Kind: journal.KindTraining, Activity: "Велосипед", Steps: 20000, Distance: 25},
+		{Time: day(10).Add(20 * time.Hour), Kind: journal.KindTraining, Activity: "Плавание", Steps: 1200, Distance: 1},
+		{Time: day(10).Add(21 * time.Hour), Kind: journal.KindDay, Steps: 1000, Distance: 0.65},
+	}
+
+	progress := Track(journal.Daily(entries), Goals{Steps: 8000})
+	require.Len(suite.T(), progress, 1)
+	assert.Equal(suite.T(), 7000, progress[0].Steps, "гребки и обороты педалей не считаются шагами")
+	assert.Equal(suite.T(), 1000, progress[0].RemainingSteps())
+	assert.InDelta(suite.T(), 31.35, progress[0].Distance, 1e-9)
+}
@@ -0,0 +1,161 @@
+// Package heartrate содержит пульсовые зоны и анализ времени в зонах
+// по ряду показаний пульса тренировки.
+package heartrate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Method — способ расчета границ пульсовых зон.
+type Method string
+
+const (
+	// MaxBased — границы зон в долях максимального пульса.
+	MaxBased Method = "max"
+	// Reserve — границы зон в долях резерва пульса (метод Карвонена):
+	// пульс в покое + доля * (максимальный пульс - пульс в покое).
+	Reserve Method = "reserve"
+)
+
+// Count — количество пульсовых зон.
+const Count = 5
+
+// bounds — границы зон 1–5 в долях максимального пульса или резерва:
+// зона i занимает [bounds[i-1], bounds[i]).
+var bounds = [Count + 1]float64{0.5, 0.6, 0.7, 0.8, 0.9, 1.0}
+
+// Parse возвращает способ расчета зон по его названию. Пустая строка
+// означает MaxBased.
+func Parse(s string) (Method, error) {
+	switch Method(s) {
+	case "":
+		return MaxBased, nil
+	case MaxBased, Reserve:
+		return Method(s), nil
+	default:
+		return "", fmt.Errorf("неизвестный способ расчета пульсовых зон %q: ожидается max или reserve", s)
+	}
+}
+
+// EstimateMax оценивает максимальный пульс по возрасту: 220 - возраст.
+func EstimateMax(age int) int {
+	return 220 - age
+}
+
+// Zone — пульсовая зона: пульс от Low включительно до High, уд/мин.
+type Zone struct {
+	Number    int
+	Low, High int
+}
+
+// Zones — пульсовые зоны 1–5 по возрастанию.
+type Zones [Count]Zone
+
+// Zones рассчитывает границы зон. maxHR — максимальный пульс, restingHR —
+// пульс в покое, нужен только для Reserve.
+func (m Method) Zones(maxHR, restingHR int) (Zones, error) {
+	if maxHR <= 0 {
+		return Zones{}, errors.New("максимальный пульс должен быть больше нуля")
+	}
+
+	base, span := 0.0, float64(maxHR)
+	switch m {
+	case "", MaxBased:
+	case Reserve:
+		if restingHR <= 0 || restingHR >= maxHR {
+			return Zones{}, errors.New("пульс в покое должен быть больше нуля и меньше максимального")
+		}
+		base, span = float64(restingHR), float64(maxHR-restingHR)
+	default:
+		return Zones{}, fmt.Errorf("неизвестный способ расчета пульсовых зон %q", m)
+	}
+
+	var z Zones
+	for i := range z {
+		z[i] = Zone{
+			Number: i + 1,
+			Low:    int(math.Round(base + bounds[i]*span)),
+			High:   int(math.Round(base + bounds[i+1]*span)),
+		}
+	}
+	return z, nil
+}
+
+// Of возвращает номер зоны для пульса hr. Пульс ниже первой зоны дает 0,
+// пульс выше максимального относится к зоне 5.
+func (z Zones) Of(hr int) int {
+	if hr < z[0].Low {
+		return 0
+	}
+	for _, zone := range z {
+		if hr < zone.High {
+			return zone.Number
+		}
+	}
+	return Count
+}
+
+// Analysis — распределение времени тренировки по пульсовым зонам.
+type Analysis struct {
+	Average int                  // средний пульс, уд/мин.
+	Max     int                  // максимальный пульс, уд/мин.
+	Time    [Count]time.Duration // время в зонах 1–5.
+	Below   time.Duration        // время ниже первой зоны.
+}
+
+// Analyze распределяет продолжительность тренировки по зонам. Показания
+// series считаются равномерно распределенными по тренировке: каждое
+// покрывает duration/len(series). Без показаний возвращается нулевой
+// результат.
+func Analyze(series []int, duration time.Duration, z Zones) Analysis {
+	var a Analysis
+	if len(series) == 0 {
+		return a
+	}
+
+	step := duration / time.Duration(len(series))
+	for _, hr := range series {
+		a.Max = max(a.Max, hr)
+		if n := z.Of(hr); n > 0 {
+			a.Time[n-1] += step
+		} else {
+			a.Below += step
+		}
+	}
+	a.Average = Average(series)
+
+	return a
+}
+
+// Average возвращает средний пульс по ряду показаний, округленный
+// до целого. Для пустого ряда возвращается 0.
+func Average(series []int) int {
+	if len(series) == 0 {
+		return 0
+	}
+
+	sum := 0
+	for _, hr := range series {
+		sum += hr
+	}
+	return int(math.Round(float64(sum) / float64(len(series))))
+}
+
+// Share возвращает долю продолжительности тренировки в зоне n (1–5);
+// для n = 0 — долю времени ниже первой зоны.
+func (a Analysis) Share(n int) float64 {
+	total := a.Below
+	for _, d := range a.Time {
+		total += d
+	}
+	if total <= 0 || n < 0 || n > Count {
+		return 0
+	}
+	if n == 0 {
+		return float64(a.Below) / float64(total)
+	}
+	return float64(a.Time[n-1]) / float64(total)
+}
@@ -0,0 +1,94 @@
+package heartrate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type HeartRateTestSuite struct {
+	suite.Suite
+}
+
+func TestHeartRateSuite(t *testing.T) {
+	suite.Run(t, new(HeartRateTestSuite))
+}
+
+func (suite *HeartRateTestSuite) TestZones() {
+	z, err := MaxBased.Zones(190, 0)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Zones{
+		{Number: 1, Low: 95, High: 114},
+		{Number: 2, Low: 114, High: 133},
+		{Number: 3, Low: 133, High: 152},
+		{Number: 4, Low: 152, High: 171},
+		{Number: 5, Low: 171, High: 190},
+	}, z)
+
+	z, err = Reserve.Zones(190, 60)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Zone{Number: 1, Low: 125, High: 138}, z[0])
+	assert.Equal(suite.T(), Zone{Number: 5, Low: 177, High: 190}, z[4])
+
+	_, err = Reserve.Zones(190, 0)
+	assert.Error(suite.T(), err)
+	_, err = MaxBased.Zones(0, 0)
+	assert.Error(suite.T(), err)
+	_, err = Method("lactate").Zones(190, 60)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *HeartRateTestSuite) TestOf() {
+	z, err := MaxBased.Zones(190, 0)
+	require.NoError(suite.T(), err)
+
+	tests := []struct {
+		hr   int
+		want int
+	}{
+		{hr: 80, want: 0},
+		{hr: 95, want: 1},
+		{hr: 113, want: 1},
+		{hr: 114, want: 2},
+		{hr: 170, want: 4},
+		{hr: 190, want: 5},
+		{hr: 200, want: 5},
+	}
+	for _, tt := range tests {
+		assert.Equal(suite.T(), tt.want, z.Of(tt.hr), tt.hr)
+	}
+}
+
+func (suite *HeartRateTestSuite) TestAnalyze() {
+	z, err := MaxBased.Zones(190, 0)
+	require.NoError(suite.T(), err)
+
+	a := Analyze([]int{80, 120, 140, 145, 185}, 50*time.Minute, z)
+	assert.Equal(suite.T(), 134, a.Average)
+	assert.Equal(suite.T(), 185, a.Max)
+	assert.Equal(suite.T(), 10*time.Minute, a.Below)
+	assert.Equal(suite.T(), [Count]time.Duration{0, 10 * time.Minute, 20 * time.Minute, 0, 10 * time.Minute}, a.Time)
+	assert.InDelta(suite.T(), 0.4, a.Share(3), 1e-9)
+	assert.InDelta(suite.T(), 0.2, a.Share(0), 1e-9)
+	assert.Zero(suite.T(), a.Share(6))
+
+	assert.Equal(suite.T(), Analysis{}, Analyze(nil, time.Hour, z))
+}
+
+func (suite *HeartRateTestSuite) TestParse() {
+	m, err := Parse("")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), MaxBased, m)
+
+	m, err = Parse("reserve")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Reserve, m)
+
+	_, err = Parse("lactate")
+	assert.Error(suite.T(), err)
+
+	assert.Equal(suite.T(), 190, EstimateMax(30))
+}
@@ -0,0 +1,377 @@
+// Package i18n содержит каталоги сообщений для отчетов трекера и названия
+// типов тренировок на разных языках. Язык по умолчанию — русский.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Lang — код языка.
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	// Default — язык по умолчанию; его каталог используется, если
+	// в каталоге выбранного языка нет нужного сообщения.
+	Default = Russian
+)
+
+// Ключи сообщений.
+const (
+	// MsgDayReport — отчет о дневной активности: шаги, дистанция и её
+	// единица, калории.
+	MsgDayReport = "report.day"
+	// MsgTrainingReport — отчет о тренировке: тип, часы, дистанция и её
+	// единица, скорость и её единица, калории.
+	MsgTrainingReport = "report.training"
+	// MsgJournalDay — итоги дня журнала: дата, шаги, дистанция и её
+	// единица, калории.
+	MsgJournalDay = "report.journal_day"
+
+	// MsgGoalDate — заголовок прогресса по целям: дата.
+	MsgGoalDate = "goal.date"
+	// MsgGoalSteps — прогресс по шагам: шаги, цель, процент, остаток шагов.
+	MsgGoalSteps = "goal.steps"
+	// MsgGoalDistance — прогресс по дистанции: дистанция, цель, единица,
+	// процент.
+	MsgGoalDistance = "goal.distance"
+	// MsgGoalCalories — прогресс по калориям: калории, цель, процент.
+	MsgGoalCalories = "goal.calories"
+	// MsgGoalMet — цель дня выполнена: длина серии в днях.
+	MsgGoalMet = "goal.met"
+	// MsgGoalNotMet — цель дня не выполнена.
+	MsgGoalNotMet = "goal.not_met"
+	// MsgGoalStreaks — серии дней с выполненной целью: текущая и лучшая.
+	MsgGoalStreaks = "goal.streaks"
+
+	// MsgPeriodWeek — заголовок итогов недели: первый и последний день.
+	MsgPeriodWeek = "period.week"
+	// MsgPeriodMonth — заголовок итогов месяца: месяц в виде 2006-01.
+	MsgPeriodMonth = "period.month"
+	// MsgPeriodTotals — итоги периода: активные дни; шаги всего и в среднем;
+	// дистанция всего, единица, в среднем, единица; калории всего
+	// и в среднем.
+	MsgPeriodTotals = "period.totals"
+	// MsgPeriodTrainings — количество тренировок по типам: список.
+	MsgPeriodTrainings = "period.trainings"
+	// MsgPeriodTrainingCount — элемент списка тренировок: тип, количество.
+	MsgPeriodTrainingCount = "period.training_count"
+	// MsgPeriodNoTrainings — в периоде не было тренировок.
+	MsgPeriodNoTrainings = "period.no_trainings"
+	// MsgPeriodLongest — самая длинная тренировка: тип, часы, дата.
+	MsgPeriodLongest = "period.longest"
+	// MsgPeriodBestPace — лучший темп: темп, единица, тип, дата.
+	MsgPeriodBestPace = "period.best_pace"
+
+	// MsgPace — средний темп тренировки: темп, единица.
+	MsgPace = "pace.pace"
+	// MsgSplitsHeader — заголовок списка сплитов.
+	MsgSplitsHeader = "pace.splits"
+	// MsgSplit — сплит: дистанция от старта, единица, время отрезка,
+	// время от старта.
+	MsgSplit = "pace.split"
+
+	// MsgHeartRate — средний пульс тренировки, уд/мин.
+	MsgHeartRate = "training.heart_rate"
+	// MsgMaxHeartRate — максимальный пульс тренировки, уд/мин.
+	MsgMaxHeartRate = "zones.max_heart_rate"
+	// MsgZonesHeader — заголовок распределения времени по пульсовым зонам.
+	MsgZonesHeader = "zones.header"
+	// MsgZone — время в зоне: номер, нижняя и верхняя граница, время,
+	// процент от тренировки.
+	MsgZone = "zones.zone"
+	// MsgZoneBelow — время ниже первой зоны: время, процент от тренировки.
+	MsgZoneBelow = "zones.below"
+	// MsgZonesNoData — в пакете нет показаний пульса.
+	MsgZonesNoData = "zones.no_data"
+
+	// activityPrefix — префикс ключей с названиями типов тренировок.
+	// Ключ строится из основного (русского) названия: "activity.Бег".
+	activityPrefix = "activity."
+	// unitPrefix — префикс ключей с обозначениями единиц измерения.
+	// Ключ строится из обозначения в пакете units: "unit.km".
+	unitPrefix = "unit."
+)
+
+// Catalog — сообщения одного языка по ключам.
+type Catalog map[string]string
+
+var (
+	catalogsMu sync.RWMutex
+	catalogs   = map[Lang]Catalog{
+		Russian: {
+			MsgDayReport:      "Количество шагов: %d.\nДистанция составила %.2f %s.\nВы сожгли %.2f ккал.\n",
+			MsgTrainingReport: "Тип тренировки: %s\nДлительность: %.2f ч.\nДистанция: %.2f %s.\nСкорость: %.2f %s\nСожгли калорий: %.2f\n",
+			MsgJournalDay:     "Дата: %s\nКоличество шагов: %d.\nДистанция составила %.2f %s.\nВы сожгли %.2f ккал.\n",
+
+			MsgGoalDate:     "Дата: %s\n",
+			MsgGoalSteps:    "Шаги: %d из %d (%.0f%%), осталось %d.\n",
+			MsgGoalDistance: "Дистанция: %.2f из %.2f %s (%.0f%%).\n",
+			MsgGoalCalories: "Калории: %.2f из %.2f ккал (%.0f%%).\n",
+			MsgGoalMet:      "Цель дня выполнена. Серия: %d дн.\n",
+			MsgGoalNotMet:   "Цель дня не выполнена.\n",
+			MsgGoalStreaks:  "Текущая серия: %d дн. Лучшая серия: %d дн.\n",
+
+			MsgPeriodWeek:  "Неделя %s — %s\n",
+			MsgPeriodMonth: "Месяц %s\n",
+			MsgPeriodTotals: "Активных дней: %d.\n" +
+				"Шаги: всего %d, в среднем %.0f в день.\n" +
+				"Дистанция: всего %.2f %s, в среднем %.2f %s в день.\n" +
+				"Калории: всего %.2f ккал, в среднем %.2f ккал в день.\n",
+			MsgPeriodTrainings:     "Тренировки: %s.\n",
+			MsgPeriodTrainingCount: "%s — %d",
+			MsgPeriodNoTrainings:   "Тренировок не было.\n",
+			MsgPeriodLongest:       "Самая длинная тренировка: %s, %.2f ч., %s.\n",
+			MsgPeriodBestPace:      "Лучший темп: %s %s (%s, %s).\n",
+
+			MsgPace:         "Темп: %s %s\n",
+			MsgSplitsHeader: "Сплиты:\n",
+			MsgSplit:        "  %.2f %s — %s (всего %s)\n",
+
+			MsgHeartRate:    "Средний пульс: %d уд/мин\n",
+			MsgMaxHeartRate: "Максимальный пульс: %d уд/мин\n",
+			MsgZonesHeader:  "Пульсовые зоны:\n",
+			MsgZone:         "  Зона %d (%d–%d уд/мин): %s (%.0f%%)\n",
+			MsgZoneBelow:    "  Ниже зоны 1: %s (%.0f%%)\n",
+			MsgZonesNoData:  "Нет данных о пульсе.\n",
+
+			unitPrefix + "km":     "км",
+			unitPrefix + "km/h":   "км/ч",
+			unitPrefix + "mi":     "мили",
+			unitPrefix + "mph":    "миль/ч",
+			unitPrefix + "min/km": "мин/км",
+			unitPrefix + "min/mi": "мин/миля",
+		},
+		English: {
+			MsgDayReport:      "Steps: %d.\nDistance: %.2f %s.\nCalories burned: %.2f kcal.\n",
+			MsgTrainingReport: "Training type: %s\nDuration: %.2f h.\nDistance: %.2f %s.\nSpeed: %.2f %s\nCalories burned: %.2f\n",
+			MsgJournalDay:     "Date: %s\nSteps: %d.\nDistance: %.2f %s.\nCalories burned: %.2f kcal.\n",
+
+			MsgGoalDate:     "Date: %s\n",
+			MsgGoalSteps:    "Steps: %d of %d (%.0f%%), %d to go.\n",
+			MsgGoalDistance: "Distance: %.2f of %.2f %s (%.0f%%).\n",
+			MsgGoalCalories: "Calories: %.2f of %.2f kcal (%.0f%%).\n",
+			MsgGoalMet:      "Daily goal met. Streak: %d days.\n",
+			MsgGoalNotMet:   "Daily goal not met.\n",
+			MsgGoalStreaks:  "Current streak: %d days. Longest streak: %d days.\n",
+
+			MsgPeriodWeek:  "Week %s — %s\n",
+			MsgPeriodMonth: "Month %s\n",
+			MsgPeriodTotals: "Active days: %d.\n" +
+				"Steps: %d total, %.0f per day on average.\n" +
+				"Distance: %.2f %s total, %.2f %s per day on average.\n" +
+				"Calories: %.2f kcal total, %.2f kcal per day on average.\n",
+			MsgPeriodTrainings:     "Trainings: %s.\n",
+			MsgPeriodTrainingCount: "%s — %d",
+			MsgPeriodNoTrainings:   "No trainings.\n",
+			MsgPeriodLongest:       "Longest training: %s, %.2f h, %s.\n",
+			MsgPeriodBestPace:      "Best pace: %s %s (%s, %s).\n",
+
+			MsgPace:         "Pace: %s %s\n",
+			MsgSplitsHeader: "Splits:\n",
+			MsgSplit:        "  %.2f %s — %s (total %s)\n",
+
+			MsgHeartRate:    "Average heart rate: %d bpm\n",
+			MsgMaxHeartRate: "Max heart rate: %d bpm\n",
+			MsgZonesHeader:  "Heart rate zones:\n",
+			MsgZone:         "  Zone %d (%d–%d bpm): %s (%.0f%%)\n",
+			MsgZoneBelow:    "  Below zone 1: %s (%.0f%%)\n",
+			MsgZonesNoData:  "No heart rate data.\n",
+
+			unitPrefix + "km":     "km",
+			unitPrefix + "km/h":   "km/h",
+			unitPrefix + "mi":     "mi",
+			unitPrefix + "mph":    "mph",
+			unitPrefix + "min/km": "min/km",
+			unitPrefix + "min/mi": "min/mi",
+
+			activityPrefix + "Бег":       "Running",
+			activityPrefix + "Ходьба":    "Walking",
+			activityPrefix + "Плавание":  "Swimming",
+			activityPrefix + "Велосипед": "Cycling",
+		},
+	}
+)
+
+// Register добавляет сообщения в каталог языка. Существующие сообщения
+// с теми же ключами заменяются. Так можно добавить новый язык или
+// перевести названия собственных типов тренировок.
+func Register(lang Lang, messages Catalog) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	c, ok := catalogs[lang]
+	if !ok {
+		c = make(Catalog, len(messages))
+		catalogs[lang] = c
+	}
+	for key, msg := range messages {
+		c[key] = msg
+	}
+}
+
+// Unregister удаляет сообщения с ключами keys из каталога языка lang.
+// Язык, в каталоге которого не осталось сообщений, удаляется.
+func Unregister(lang Lang, keys ...string) {
+	catalogsMu.Lock()
+	defer catalogsMu.Unlock()
+
+	c, ok := catalogs[lang]
+	if !ok {
+		return
+	}
+	for _, key := range keys {
+		delete(c, key)
+	}
+	if len(c) == 0 {
+		delete(catalogs, lang)
+	}
+}
+
+// ActivityKey возвращает ключ каталога для названия типа тренировки.
+func ActivityKey(name string) string {
+	return activityPrefix + name
+}
+
+// Parse возвращает язык по коду. Пустая строка означает Default.
+func Parse(s string) (Lang, error) {
+	if s == "" {
+		return Default, nil
+	}
+
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	if _, ok := catalogs[Lang(s)]; !ok {
+		return "", fmt.Errorf("неизвестный язык %q", s)
+	}
+	return Lang(s), nil
+}
+
+// Languages возвращает отсортированный список доступных языков.
+func Languages() []Lang {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	langs := make([]Lang, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
+}
+
+// message возвращает сообщение по ключу с откатом на язык по умолчанию.
+// Если сообщения нет нигде, возвращается сам ключ.
+func (l Lang) message(key string) (string, bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	if msg, ok := catalogs[l][key]; ok {
+		return msg, true
+	}
+	if msg, ok := catalogs[Default][key]; ok {
+		return msg, true
+	}
+	return key, false
+}
+
+// Sprintf форматирует сообщение с указанным ключом на языке l.
+func (l Lang) Sprintf(key string, args ...any) string {
+	msg, _ := l.message(key)
+	return fmt.Sprintf(msg, args...)
+}
+
+// Activity возвращает название типа тренировки на языке l. name —
+// основное название типа; если перевода нет, оно возвращается как есть.
+func (l Lang) Activity(name string) string {
+	if msg, ok := l.message(ActivityKey(name)); ok {
+		return msg
+	}
+	return name
+}
+
+// CanonicalActivity ищет во всех каталогах перевод названия типа
+// тренировки и возвращает его основное название.
+func CanonicalActivity(name string) (string, bool) {
+	catalogsMu.RLock()
+	defer catalogsMu.RUnlock()
+
+	for _, c := range catalogs {
+		for key, msg := range c {
+			if canonical, ok := strings.CutPrefix(key, activityPrefix); ok && msg == name {
+				return canonical, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Locale — язык и система единиц отчетов. Нулевое значение означает язык
+// по умолчанию и метрическую систему.
+type Locale struct {
+	Lang  Lang
+	Units units.System
+}
+
+func (l Locale) lang() Lang {
+	if l.Lang == "" {
+		return Default
+	}
+	return l.Lang
+}
+
+// Sprintf форматирует сообщение на языке локали.
+func (l Locale) Sprintf(key string, args ...any) string {
+	return l.lang().Sprintf(key, args...)
+}
+
+// Activity возвращает название типа тренировки на языке локали.
+func (l Locale) Activity(name string) string {
+	return l.lang().Activity(name)
+}
+
+// Distance переводит дистанцию из километров в единицы локали и возвращает
+// её вместе с обозначением единицы.
+func (l Locale) Distance(km float64) (float64, string) {
+	return l.Units.Distance(km), l.unit(l.Units.DistanceUnit())
+}
+
+// Speed переводит скорость из км/ч в единицы локали и возвращает её вместе
+// с обозначением единицы.
+func (l Locale) Speed(kmh float64) (float64, string) {
+	return l.Units.Speed(kmh), l.unit(l.Units.SpeedUnit())
+}
+
+// Pace переводит темп из времени на километр в единицы локали и возвращает
+// его в виде Clock вместе с обозначением единицы.
+func (l Locale) Pace(perKm time.Duration) (string, string) {
+	return Clock(l.Units.Pace(perKm)), l.unit(l.Units.PaceUnit())
+}
+
+// Clock форматирует продолжительность с точностью до секунды: "5:07"
+// или "1:02:30", если она не меньше часа.
+func Clock(d time.Duration) string {
+	d = d.Round(time.Second)
+	h, m, s := int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60
+
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%d:%02d", m, s)
+}
+
+func (l Locale) unit(symbol string) string {
+	if msg, ok := l.lang().message(unitPrefix + symbol); ok {
+		return msg
+	}
+	return symbol
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type I18nTestSuite struct {
+	suite.Suite
+}
+
+func TestI18nSuite(t *testing.T) {
+	suite.Run(t, new(I18nTestSuite))
+}
+
+func (suite *I18nTestSuite) TestSprintf() {
+	assert.Equal(suite.T(), "Количество шагов: 10.\nДистанция составила 1.00 км.\nВы сожгли 2.00 ккал.\n",
+		Russian.Sprintf(MsgDayReport, 10, 1.0, "км", 2.0))
+	assert.Equal(suite.T(), "Steps: 10.\nDistance: 1.00 km.\nCalories burned: 2.00 kcal.\n",
+		English.Sprintf(MsgDayReport, 10, 1.0, "km", 2.0))
+}
+
+func (suite *I18nTestSuite) TestRegisterLanguage() {
+	Register("de", Catalog{
+		MsgDayReport:            "Schritte: %d.\n",
+		ActivityKey("Плавание"): "Schwimmen",
+	})
+
+	lang, err := Parse("de")
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), Languages(), lang)
+
+	assert.Equal(suite.T(), "Schritte: 5.\n", lang.Sprintf(MsgDayReport, 5))
+	assert.Equal(suite.T(), "Schwimmen", lang.Activity("Плавание"))
+	// Сообщения без перевода берутся из каталога по умолчанию.
+	assert.Equal(suite.T(), "Бег", lang.Activity("Бег"))
+	assert.Equal(suite.T(), "Дата: x\nКоличество шагов: 1.\nДистанция составила 1.00 км.\nВы сожгли 1.00 ккал.\n",
+		lang.Sprintf(MsgJournalDay, "x", 1, 1.0, "км", 1.0))
+
+	name, ok := CanonicalActivity("Schwimmen")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Плавание", name)
+
+	Unregister("de", MsgDayReport, ActivityKey("Плавание"))
+	_, err = Parse("de")
+	assert.Error(suite.T(), err, "язык без сообщений удаляется")
+	_, ok = CanonicalActivity("Schwimmen")
+	assert.False(suite.T(), ok)
+}
+
+func (suite *I18nTestSuite) TestActivity() {
+	assert.Equal(suite.T(), "Running", English.Activity("Бег"))
+	assert.Equal(suite.T(), "Бег", Russian.Activity("Бег"))
+	assert.Equal(suite.T(), "Гребля", English.Activity("Гребля"))
+
+	name, ok := CanonicalActivity("Walking")
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), "Ходьба", name)
+
+	_, ok = CanonicalActivity("Report")
+	assert.False(suite.T(), ok)
+
+	_, err := Parse("xx")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *I18nTestSuite) TestPace() {
+	pace, unit := Locale{}.Pace(5*time.Minute + 7*time.Second)
+	assert.Equal(suite.T(), "5:07", pace)
+	assert.Equal(suite.T(), "мин/км", unit)
+
+	pace, unit = Locale{Lang: English, Units: units.Imperial}.Pace(5 * time.Minute)
+	assert.Equal(suite.T(), "8:03", pace)
+	assert.Equal(suite.T(), "min/mi", unit)
+
+	pace, _ = Locale{}.Pace(62*time.Minute + 30*time.Second)
+	assert.Equal(suite.T(), "1:02:30", pace)
+}
+
+func (suite *I18nTestSuite) TestLocale() {
+	dist, unit := Locale{}.Distance(10)
+	assert.InDelta(suite.T(), 10, dist, 1e-9)
+	assert.Equal(suite.T(), "км", unit)
+
+	dist, unit = Locale{Lang: English, Units: units.Imperial}.Distance(units.KmInMile)
+	assert.InDelta(suite.T(), 1, dist, 1e-9)
+	assert.Equal(suite.T(), "mi", unit)
+
+	speed, unit := Locale{Units: units.Imperial}.Speed(units.KmInMile)
+	assert.InDelta(suite.T(), 1, speed, 1e-9)
+	assert.Equal(suite.T(), "миль/ч", unit)
+}
@@ -0,0 +1,253 @@
+// Package journal хранит историю дневной активности и тренировок в локальном
+// файле и строит по ней дневные итоги.
+package journal
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// Kind — вид записи в журнале.
+type Kind string
+
+const (
+	KindDay      Kind = "day"      // пакет дневной активности.
+	KindTraining Kind = "training" // тренировка.
+)
+
+// dateLayout — формат даты в отчетах журнала.
+const dateLayout = "2006-01-02"
+
+// Entry — одна запись журнала. Вместе с исходным пакетом хранятся
+// рассчитанные значения, чтобы история не менялась при изменении
+// профиля пользователя.
+type Entry struct {
+	Time     time.Time     `json:"time"`
+	Kind     Kind          `json:"kind"`
+	Packet   string        `json:"packet"`
+	Activity string        `json:"activity,omitempty"`
+	Steps    int           `json:"steps"`
+	Duration time.Duration `json:"duration"`
+	Distance float64       `json:"distance_km"`
+	Calories float64       `json:"calories_kcal"`
+}
+
+// Journal — журнал активности, хранящийся в файле в формате JSON Lines.
+type Journal struct {
+	mu   sync.Mutex
+	path string
+}
+
+// Open открывает журнал по указанному пути. Файл и каталоги создаются
+// при первой записи.
+func Open(path string) (*Journal, error) {
+	if path == "" {
+		return nil, errors.New("не указан путь к журналу")
+	}
+	return &Journal{path: path}, nil
+}
+
+// AddDay рассчитывает пакет дневной активности и сохраняет его в журнал.
+// Время записи берется из метки времени пакета, если она есть (метка без
+// даты относится ко дню at), иначе — at.
+func (j *Journal) AddDay(at time.Time, packet string, p profile.Profile) (Entry, error) {
+	action, err := daysteps.CalculateFor(packet, p)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{
+		Time:     action.Stamp.On(at),
+		Kind:     KindDay,
+		Packet:   packet,
+		Steps:    action.Steps,
+		Duration: action.Duration,
+		Distance: action.Distance,
+		Calories: action.Calories,
+	}
+	return e, j.append(e)
+}
+
+// AddTraining рассчитывает тренировку и сохраняет её в журнал. Время
+// записи выбирается так же, как в AddDay.
+func (j *Journal) AddTraining(at time.Time, packet string, p profile.Profile) (Entry, error) {
+	summary, err := spentcalories.CalculateFor(packet, p)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{
+		Time:     summary.Stamp.On(at),
+		Kind:     KindTraining,
+		Packet:   packet,
+		Activity: summary.Activity,
+		Steps:    summary.Steps,
+		Duration: summary.Duration,
+		Distance: summary.Distance,
+		Calories: summary.Calories,
+	}
+	return e, j.append(e)
+}
+
+func (j *Journal) append(e Entry) error {
+	line, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(j.path), 0o755); err != nil {
+		return fmt.Errorf("не удалось создать каталог журнала: %w", err)
+	}
+
+	f, err := os.OpenFile(j.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("не удалось открыть журнал: %w", err)
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("не удалось записать в журнал: %w", err)
+	}
+
+	return f.Close()
+}
+
+// Entries возвращает все записи журнала в порядке времени.
+func (j *Journal) Entries() ([]Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	f, err := os.Open(j.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("не удалось открыть журнал: %w", err)
+	}
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: поврежденная запись: %w", j.path, n, err)
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("ошибка чтения журнала: %w", err)
+	}
+
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].Time.Before(entries[b].Time)
+	})
+
+	return entries, nil
+}
+
+// Daily возвращает дневные итоги по всем записям журнала.
+func (j *Journal) Daily() ([]DayTotal, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return nil, err
+	}
+	return Daily(entries), nil
+}
+
+// DayTotal — итоги одного дня.
+type DayTotal struct {
+	Date time.Time // полночь дня в часовом поясе записей.
+	// Steps — шаги за день: из пакетов дневной активности и тренировок
+	// по шагам (см. spentcalories.CountsSteps). Гребки и обороты педалей
+	// не учитываются.
+	Steps    int
+	Distance float64 // суммарная дистанция в километрах.
+	Calories float64 // суммарные калории, ккал.
+	Entries  []Entry // записи дня в порядке времени.
+}
+
+// String возвращает итоги дня в текстовом виде на языке по умолчанию
+// и в метрических единицах.
+func (d DayTotal) String() string {
+	return d.Format(i18n.Locale{})
+}
+
+// Format возвращает итоги дня на языке и в единицах локали.
+func (d DayTotal) Format(loc i18n.Locale) string {
+	dist, unit := loc.Distance(d.Distance)
+	return loc.Sprintf(i18n.MsgJournalDay, d.Date.Format(dateLayout), d.Steps, dist, unit, d.Calories)
+}
+
+// Daily группирует записи по календарным дням и считает по ним итоги.
+// В итоги входят и пакеты дневной активности, и тренировки; в шаги —
+// только записи с шагами (см. DayTotal.Steps).
+// Дни возвращаются в порядке возрастания даты.
+func Daily(entries []Entry) []DayTotal {
+	byDate := make(map[string]*DayTotal)
+	var order []string
+
+	for _, e := range entries {
+		key := e.Time.Format(dateLayout)
+		d, ok := byDate[key]
+		if !ok {
+			y, m, day := e.Time.Date()
+			d = &DayTotal{Date: time.Date(y, m, day, 0, 0, 0, 0, e.Time.Location())}
+			byDate[key] = d
+			order = append(order, key)
+		}
+		if e.countsSteps() {
+			d.Steps += e.Steps
+		}
+		d.Distance += e.Distance
+		d.Calories += e.Calories
+		d.Entries = append(d.Entries, e)
+	}
+
+	sort.Strings(order)
+
+	days := make([]DayTotal, 0, len(order))
+	for _, key := range order {
+		days = append(days, *byDate[key])
+	}
+
+	return days
+}
+
+// countsSteps сообщает, что Steps записи — шаги, а не гребки или обороты
+// педалей.
+func (e Entry) countsSteps() bool {
+	return e.Kind == KindDay || spentcalories.CountsSteps(e.Activity)
+}
+
+// Day возвращает итоги за указанную дату. Если записей за этот день нет,
+// возвращаются нулевые итоги.
+func Day(entries []Entry, date time.Time) DayTotal {
+	key := date.Format(dateLayout)
+	for _, d := range Daily(entries) {
+		if d.Date.Format(dateLayout) == key {
+			return d
+		}
+	}
+
+	y, m, day := date.Date()
+	return DayTotal{Date: time.Date(y, m, day, 0, 0, 0, 0, date.Location())}
+}
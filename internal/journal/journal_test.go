@@ -0,0 +1,129 @@
+package journal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var user = profile.Profile{Weight: 75, Height: 1.75}
+
+type JournalTestSuite struct {
+	suite.Suite
+	journal *Journal
+	path    string
+}
+
+func TestJournalSuite(t *testing.T) {
+	suite.Run(t, new(JournalTestSuite))
+}
+
+func (suite *JournalTestSuite) SetupTest() {
+	suite.path = filepath.Join(suite.T().TempDir(), "data", "journal.jsonl")
+
+	j, err := Open(suite.path)
+	require.NoError(suite.T(), err)
+	suite.journal = j
+}
+
+func (suite *JournalTestSuite) TestEmpty() {
+	entries, err := suite.journal.Entries()
+	require.NoError(suite.T(), err)
+	assert.Empty(suite.T(), entries)
+}
+
+func (suite *JournalTestSuite) TestDaily() {
+	day1 := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+
+	_, err := suite.journal.AddDay(day2, "6000,1h00m", user)
+	require.NoError(suite.T(), err)
+	_, err = suite.journal.AddDay(day1, "3000,30m", user)
+	require.NoError(suite.T(), err)
+	e, err := suite.journal.AddTraining(day2.Add(2*time.Hour), "6000,Бег,1h00m", user)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Бег", e.Activity)
+
+	_, err = suite.journal.AddDay(day2, "bad", user)
+	assert.Error(suite.T(), err)
+
+	days, err := suite.journal.Daily()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), days, 2)
+
+	assert.Equal(suite.T(), time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC), days[0].Date)
+	assert.Equal(suite.T(), 3000, days[0].Steps)
+	assert.InDelta(suite.T(), 1.95, days[0].Distance, 1e-9)
+
+	assert.Equal(suite.T(), 12000, days[1].Steps)
+	assert.InDelta(suite.T(), 3.9+4.725, days[1].Distance, 1e-9)
+	assert.InDelta(suite.T(), 177.19+354.38, days[1].Calories, 0.01)
+	require.Len(suite.T(), days[1].Entries, 2)
+	assert.Equal(suite.T(), KindDay, days[1].Entries[0].Kind)
+	assert.Equal(suite.T(), KindTraining, days[1].Entries[1].Kind)
+
+	entries, err := suite.journal.Entries()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 6000, Day(entries, day2).Entries[0].Steps)
+	assert.Zero(suite.T(), Day(entries, day2.AddDate(0, 0, 1)).Steps)
+}
+
+func (suite *JournalTestSuite) TestCorruptedFile() {
+	require.NoError(suite.T(), os.MkdirAll(filepath.Dir(suite.path), 0o755))
+	require.NoError(suite.T(), os.WriteFile(suite.path, []byte("{not json}\n"), 0o644))
+
+	_, err := suite.journal.Entries()
+	assert.ErrorContains(suite.T(), err, ":1:")
+}
+
+func (suite *JournalTestSuite) TestDayTotalJSON() {
+	at := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+	_, err := suite.journal.AddDay(at, "6000,1h00m", user)
+	require.NoError(suite.T(), err)
+
+	days, err := suite.journal.Daily()
+	require.NoError(suite.T(), err)
+
+	data, err := json.Marshal(days[0])
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{
+		"date": "2026-10-17",
+		"steps": 6000,
+		"distance_km": 3.9,
+		"calories_kcal": 177.1875,
+		"entries": [{
+			"time": "2026-10-17T08:00:00Z",
+			"kind": "day",
+			"packet": "6000,1h00m",
+			"steps": 6000,
+			"duration_s": 3600,
+			"distance_km": 3.9,
+			"calories_kcal": 177.1875
+		}]
+	}`, string(data))
+}
+
+func (suite *JournalTestSuite) TestStampedPackets() {
+	at := time.Date(2026, 10, 17, 23, 0, 0, 0, time.UTC)
+
+	e, err := suite.journal.AddDay(at, "v2,08:15,3000,30m", user)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Date(2026, 10, 17, 8, 15, 0, 0, time.UTC), e.Time)
+
+	e, err = suite.journal.AddTraining(at, "v2,2026-10-16T18:00:00Z,6000,Бег,1h00m", user)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), e.Time)
+
+	days, err := suite.journal.Daily()
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), days, 2)
+	assert.Equal(suite.T(), 6000, days[0].Steps)
+	assert.Equal(suite.T(), 3000, days[1].Steps)
+}
@@ -0,0 +1,116 @@
+package journal
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// dayTotalJSON — JSON-представление DayTotal в отчетах.
+type dayTotalJSON struct {
+	Date     string      `json:"date"`
+	Steps    int         `json:"steps"`
+	Distance float64     `json:"distance_km"`
+	Calories float64     `json:"calories_kcal"`
+	Entries  []entryJSON `json:"entries"`
+}
+
+// entryJSON — запись журнала в отчетах. В отличие от формата хранения
+// продолжительность передается в секундах, как в остальных отчетах.
+type entryJSON struct {
+	Time     time.Time `json:"time"`
+	Kind     Kind      `json:"kind"`
+	Packet   string    `json:"packet"`
+	Activity string    `json:"activity,omitempty"`
+	Steps    int       `json:"steps"`
+	Duration float64   `json:"duration_s"`
+	Distance float64   `json:"distance_km"`
+	Calories float64   `json:"calories_kcal"`
+}
+
+// MarshalJSON кодирует итоги дня в JSON. Дата передается в формате 2006-01-02.
+func (d DayTotal) MarshalJSON() ([]byte, error) {
+	entries := make([]entryJSON, 0, len(d.Entries))
+	for _, e := range d.Entries {
+		entries = append(entries, entryJSON{
+			Time:     e.Time,
+			Kind:     e.Kind,
+			Packet:   e.Packet,
+			Activity: e.Activity,
+			Steps:    e.Steps,
+			Duration: e.Duration.Seconds(),
+			Distance: e.Distance,
+			Calories: e.Calories,
+		})
+	}
+
+	return json.Marshal(dayTotalJSON{
+		Date:     d.Date.Format(dateLayout),
+		Steps:    d.Steps,
+		Distance: d.Distance,
+		Calories: d.Calories,
+		Entries:  entries,
+	})
+}
+
+// summaryJSON — JSON-представление Summary в отчетах.
+type summaryJSON struct {
+	Period          Period              `json:"period"`
+	Start           string              `json:"start"`
+	End             string              `json:"end"`
+	ActiveDays      int                 `json:"active_days"`
+	Steps           int                 `json:"steps"`
+	Distance        float64             `json:"distance_km"`
+	Calories        float64             `json:"calories_kcal"`
+	AverageSteps    float64             `json:"avg_steps"`
+	AverageDistance float64             `json:"avg_distance_km"`
+	AverageCalories float64             `json:"avg_calories_kcal"`
+	Trainings       map[string]int      `json:"trainings"`
+	Longest         *trainingRecordJSON `json:"longest_training,omitempty"`
+	BestPace        *trainingRecordJSON `json:"best_pace,omitempty"`
+}
+
+// trainingRecordJSON — тренировка в итогах периода. Темп передается
+// в секундах на километр.
+type trainingRecordJSON struct {
+	Activity string    `json:"activity"`
+	Time     time.Time `json:"time"`
+	Duration float64   `json:"duration_s"`
+	Pace     float64   `json:"pace_s_per_km,omitempty"`
+}
+
+func recordJSON(r *TrainingRecord) *trainingRecordJSON {
+	if r == nil {
+		return nil
+	}
+	return &trainingRecordJSON{
+		Activity: r.Activity,
+		Time:     r.Time,
+		Duration: r.Duration.Seconds(),
+		Pace:     r.Pace.Seconds(),
+	}
+}
+
+// MarshalJSON кодирует итоги периода в JSON. Start — первый день периода,
+// End — последний, оба в формате 2006-01-02.
+func (s Summary) MarshalJSON() ([]byte, error) {
+	trainings := s.Trainings
+	if trainings == nil {
+		trainings = map[string]int{}
+	}
+
+	return json.Marshal(summaryJSON{
+		Period:          s.Period,
+		Start:           s.Start.Format(dateLayout),
+		End:             s.End.AddDate(0, 0, -1).Format(dateLayout),
+		ActiveDays:      s.ActiveDays,
+		Steps:           s.Steps,
+		Distance:        s.Distance,
+		Calories:        s.Calories,
+		AverageSteps:    s.AverageSteps(),
+		AverageDistance: s.AverageDistance(),
+		AverageCalories: s.AverageCalories(),
+		Trainings:       trainings,
+		Longest:         recordJSON(s.Longest),
+		BestPace:        recordJSON(s.BestPace),
+	})
+}
@@ -0,0 +1,207 @@
+package journal
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// Period — длина периода итогов.
+type Period string
+
+const (
+	Week  Period = "week"  // неделя с понедельника по воскресенье.
+	Month Period = "month" // календарный месяц.
+)
+
+// ParsePeriod возвращает период по названию.
+func ParsePeriod(s string) (Period, error) {
+	switch Period(s) {
+	case Week, Month:
+		return Period(s), nil
+	default:
+		return "", fmt.Errorf("неизвестный период %q: ожидается week или month", s)
+	}
+}
+
+// start возвращает начало периода, в который попадает t.
+func (p Period) start(t time.Time) time.Time {
+	y, m, d := t.Date()
+	if p == Month {
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	}
+	// Неделя начинается с понедельника: time.Sunday == 0.
+	offset := (int(t.Weekday()) + 6) % 7
+	return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+}
+
+// next возвращает начало следующего периода.
+func (p Period) next(start time.Time) time.Time {
+	if p == Month {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// TrainingRecord — тренировка, отмеченная в итогах периода.
+type TrainingRecord struct {
+	Activity string
+	Time     time.Time
+	Duration time.Duration
+	Pace     time.Duration // время на километр.
+}
+
+// Summary — итоги периода. Средние значения считаются по активным дням —
+// дням, в которые есть записи.
+type Summary struct {
+	Period     Period
+	Start      time.Time      // полночь первого дня периода.
+	End        time.Time      // полночь первого дня следующего периода.
+	ActiveDays int            // дни с записями.
+	Steps      int            // шаги всего.
+	Distance   float64        // дистанция всего в километрах.
+	Calories   float64        // калории всего, ккал.
+	Trainings  map[string]int // количество тренировок по основным названиям типов.
+	// Longest — самая длинная тренировка периода, nil — тренировок не было.
+	Longest *TrainingRecord
+	// BestPace — тренировка с лучшим (наименьшим) темпом среди тренировок
+	// с ненулевой дистанцией тех типов, для которых считается темп
+	// (см. spentcalories.HasPace), nil — таких тренировок не было.
+	// Велосипед в сравнении не участвует: его темп всегда был бы лучшим.
+	BestPace *TrainingRecord
+}
+
+// AverageSteps возвращает среднее количество шагов за активный день.
+func (s Summary) AverageSteps() float64 {
+	return s.average(float64(s.Steps))
+}
+
+// AverageDistance возвращает среднюю дистанцию за активный день в километрах.
+func (s Summary) AverageDistance() float64 {
+	return s.average(s.Distance)
+}
+
+// AverageCalories возвращает средние калории за активный день.
+func (s Summary) AverageCalories() float64 {
+	return s.average(s.Calories)
+}
+
+func (s Summary) average(total float64) float64 {
+	if s.ActiveDays == 0 {
+		return 0
+	}
+	return total / float64(s.ActiveDays)
+}
+
+// String возвращает итоги периода в текстовом виде на языке по умолчанию
+// и в метрических единицах.
+func (s Summary) String() string {
+	return s.Format(i18n.Locale{})
+}
+
+// Format возвращает итоги периода на языке и в единицах локали.
+func (s Summary) Format(loc i18n.Locale) string {
+	var b strings.Builder
+
+	if s.Period == Month {
+		b.WriteString(loc.Sprintf(i18n.MsgPeriodMonth, s.Start.Format("2006-01")))
+	} else {
+		b.WriteString(loc.Sprintf(i18n.MsgPeriodWeek, s.Start.Format(dateLayout), s.End.AddDate(0, 0, -1).Format(dateLayout)))
+	}
+
+	dist, unit := loc.Distance(s.Distance)
+	avgDist, _ := loc.Distance(s.AverageDistance())
+	b.WriteString(loc.Sprintf(i18n.MsgPeriodTotals, s.ActiveDays,
+		s.Steps, s.AverageSteps(), dist, unit, avgDist, unit, s.Calories, s.AverageCalories()))
+
+	if len(s.Trainings) == 0 {
+		b.WriteString(loc.Sprintf(i18n.MsgPeriodNoTrainings))
+		return b.String()
+	}
+
+	counts := make([]string, 0, len(s.Trainings))
+	for _, name := range sortedActivities(s.Trainings) {
+		counts = append(counts, loc.Sprintf(i18n.MsgPeriodTrainingCount, loc.Activity(name), s.Trainings[name]))
+	}
+	b.WriteString(loc.Sprintf(i18n.MsgPeriodTrainings, strings.Join(counts, ", ")))
+
+	if s.Longest != nil {
+		b.WriteString(loc.Sprintf(i18n.MsgPeriodLongest,
+			loc.Activity(s.Longest.Activity), s.Longest.Duration.Hours(), s.Longest.Time.Format(dateLayout)))
+	}
+	if s.BestPace != nil {
+		pace, paceUnit := loc.Pace(s.BestPace.Pace)
+		b.WriteString(loc.Sprintf(i18n.MsgPeriodBestPace,
+			pace, paceUnit, loc.Activity(s.BestPace.Activity), s.BestPace.Time.Format(dateLayout)))
+	}
+
+	return b.String()
+}
+
+// sortedActivities возвращает типы тренировок по убыванию количества,
+// при равенстве — по названию.
+func sortedActivities(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// Summaries группирует записи по неделям или месяцам и считает по ним
+// итоги. Периоды без записей пропускаются; периоды возвращаются
+// в порядке возрастания даты.
+func Summaries(entries []Entry, period Period) []Summary {
+	var summaries []Summary
+	for _, d := range Daily(entries) {
+		start := period.start(d.Date)
+		if len(summaries) == 0 || !summaries[len(summaries)-1].Start.Equal(start) {
+			summaries = append(summaries, Summary{
+				Period:    period,
+				Start:     start,
+				End:       period.next(start),
+				Trainings: make(map[string]int),
+			})
+		}
+
+		s := &summaries[len(summaries)-1]
+		s.ActiveDays++
+		s.Steps += d.Steps
+		s.Distance += d.Distance
+		s.Calories += d.Calories
+
+		for _, e := range d.Entries {
+			if e.Kind == KindTraining {
+				s.addTraining(e)
+			}
+		}
+	}
+
+	return summaries
+}
+
+func (s *Summary) addTraining(e Entry) {
+	s.Trainings[e.Activity]++
+
+	record := &TrainingRecord{Activity: e.Activity, Time: e.Time, Duration: e.Duration}
+	if e.Distance > 0 {
+		record.Pace = time.Duration(float64(e.Duration) / e.Distance)
+	}
+
+	if s.Longest == nil || e.Duration > s.Longest.Duration {
+		s.Longest = record
+	}
+	if record.Pace > 0 && spentcalories.HasPace(e.Activity) && (s.BestPace == nil || record.Pace < s.BestPace.Pace) {
+		s.BestPace = record
+	}
+}
@@ -0,0 +1,145 @@
+package journal
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+func at(month time.Month, day, hour int) time.Time {
+	return time.Date(2026, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+// periodEntries — записи за три недели: 12–18 и 26 октября, 2 ноября.
+var periodEntries = []Entry{
+	{Time: at(10, 12, 8), Kind: KindDay, Steps: 8000, Distance: 5.2, Calories: 250},
+	{Time: at(10, 13, 18), Kind: KindTraining, Activity: "Бег", Steps: 6000, Duration: 30 * time.Minute, Distance: 6, Calories: 400},
+	{Time: at(10, 15, 18), Kind: KindTraining, Activity: "Бег", Steps: 9000, Duration: time.Hour, Distance: 10, Calories: 700},
+	{Time: at(10, 18, 10), Kind: KindTraining, Activity: "Ходьба", Steps: 12000, Duration: 2 * time.Hour, Distance: 9, Calories: 350},
+	{Time: at(10, 18, 20), Kind: KindDay, Steps: 1000, Distance: 0.65, Calories: 30},
+	{Time: at(10, 26, 9), Kind: KindDay, Steps: 5000, Distance: 3.25, Calories: 150},
+	{Time: at(11, 2, 9), Kind: KindTraining, Activity: "Плавание", Steps: 1200, Duration: 40 * time.Minute, Calories: 300},
+}
+
+type PeriodTestSuite struct {
+	suite.Suite
+}
+
+func TestPeriodSuite(t *testing.T) {
+	suite.Run(t, new(PeriodTestSuite))
+}
+
+func (suite *PeriodTestSuite) TestParsePeriod() {
+	p, err := ParsePeriod("month")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Month, p)
+
+	_, err = ParsePeriod("year")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *PeriodTestSuite) TestWeeks() {
+	weeks := Summaries(periodEntries, Week)
+	require.Len(suite.T(), weeks, 3)
+
+	w := weeks[0]
+	assert.Equal(suite.T(), at(10, 12, 0), w.Start)
+	assert.Equal(suite.T(), at(10, 19, 0), w.End)
+	assert.Equal(suite.T(), 4, w.ActiveDays)
+	assert.Equal(suite.T(), 36000, w.Steps)
+	assert.InDelta(suite.T(), 30.85, w.Distance, 1e-9)
+	assert.InDelta(suite.T(), 9000, w.AverageSteps(), 1e-9)
+	assert.InDelta(suite.T(), 432.5, w.AverageCalories(), 1e-9)
+	assert.Equal(suite.T(), map[string]int{"Бег": 2, "Ходьба": 1}, w.Trainings)
+
+	require.NotNil(suite.T(), w.Longest)
+	assert.Equal(suite.T(), "Ходьба", w.Longest.Activity)
+	require.NotNil(suite.T(), w.BestPace)
+	assert.Equal(suite.T(), at(10, 13, 18), w.BestPace.Time)
+	assert.Equal(suite.T(), 5*time.Minute, w.BestPace.Pace)
+
+	assert.Equal(suite.T(), at(10, 26, 0), weeks[1].Start)
+	assert.Empty(suite.T(), weeks[1].Trainings)
+	assert.Nil(suite.T(), weeks[1].Longest)
+
+	// 2 ноября 2026 года — понедельник.
+	assert.Equal(suite.T(), at(11, 2, 0), weeks[2].Start)
+	assert.Nil(suite.T(), weeks[2].BestPace, "у плавания нет дистанции по шагам")
+}
+
+func (suite *PeriodTestSuite) TestMonths() {
+	months := Summaries(periodEntries, Month)
+	require.Len(suite.T(), months, 2)
+	assert.Equal(suite.T(), at(10, 1, 0), months[0].Start)
+	assert.Equal(suite.T(), 5, months[0].ActiveDays)
+	assert.Equal(suite.T(), 41000, months[0].Steps)
+	assert.Equal(suite.T(), at(12, 1, 0), months[1].End)
+}
+
+func (suite *PeriodTestSuite) TestFormat() {
+	w := Summaries(periodEntries, Week)[0]
+	assert.Equal(suite.T(), "Неделя 2026-10-12 — 2026-10-18\n"+
+		"Активных дней: 4.\n"+
+		"Шаги: всего 36000, в среднем 9000 в день.\n"+
+		"Дистанция: всего 30.85 км, в среднем 7.71 км в день.\n"+
+		"Калории: всего 1730.00 ккал, в среднем 432.50 ккал в день.\n"+
+		"Тренировки: Бег — 2, Ходьба — 1.\n"+
+		"Самая длинная тренировка: Ходьба, 2.00 ч., 2026-10-18.\n"+
+		"Лучший темп: 5:00 мин/км (Бег, 2026-10-13).\n", w.String())
+
+	m := Summaries(periodEntries, Month)[1]
+	text := m.Format(i18n.Locale{Lang: i18n.English})
+	assert.Contains(suite.T(), text, "Month 2026-11\n")
+	assert.Contains(suite.T(), text, "Trainings: Swimming — 1.\n")
+	assert.NotContains(suite.T(), text, "Best pace")
+
+	assert.Contains(suite.T(), Summaries(periodEntries, Week)[1].String(), "Тренировок не было.\n")
+}
+
+func (suite *PeriodTestSuite) TestJSON() {
+	data, err := json.Marshal(Summaries(periodEntries, Week)[0])
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{
+		"period": "week",
+		"start": "2026-10-12",
+		"end": "2026-10-18",
+		"active_days": 4,
+		"steps": 36000,
+		"distance_km": 30.85,
+		"calories_kcal": 1730,
+		"avg_steps": 9000,
+		"avg_distance_km": 7.7125,
+		"avg_calories_kcal": 432.5,
+		"trainings": {"Бег": 2, "Ходьба": 1},
+		"longest_training": {"activity": "Ходьба", "time": "2026-10-18T10:00:00Z", "duration_s": 7200, "pace_s_per_km": 800},
+		"best_pace": {"activity": "Бег", "time": "2026-10-13T18:00:00Z", "duration_s": 1800, "pace_s_per_km": 300}
+	}`, string(data))
+}
+
+func (suite *PeriodTestSuite) TestMixedRunAndRide() {
+	entries := []Entry{
+		{Time: at(10, 12, 8), Kind: KindDay, Steps: 2000, Distance: 1.3, Calories: 60},
+		{Time: at(10, 13, 18), Kind: KindTraining, Activity: "Бег", Steps: 6000, Duration: 30 * time.Minute, Distance: 6, Calories: 400},
+		{Time: at(10, 14, 18), Kind: KindTraining, Activity: "Велосипед", Steps: 20000, Duration: time.Hour, Distance: 25, Calories: 600},
+	}
+
+	weeks := Summaries(entries, Week)
+	require.Len(suite.T(), weeks, 1)
+
+	w := weeks[0]
+	assert.Equal(suite.T(), 8000, w.Steps, "обороты педалей не считаются шагами")
+	assert.InDelta(suite.T(), 8000.0/3, w.AverageSteps(), 1e-9)
+	require.NotNil(suite.T(), w.BestPace)
+	assert.Equal(suite.T(), "Бег", w.BestPace.Activity)
+	assert.Equal(suite.T(), 5*time.Minute, w.BestPace.Pace)
+	assert.Contains(suite.T(), w.String(), "Лучший темп: 5:00 мин/км (Бег, 2026-10-13).\n")
+
+	rides := Summaries(entries[2:], Week)
+	require.Len(suite.T(), rides, 1)
+	assert.Nil(suite.T(), rides[0].BestPace)
+}
@@ -0,0 +1,167 @@
+// Package packet содержит общий разбор полей пакетов данных и типизированные
+// ошибки разбора, которые можно проверять через errors.Is и errors.As.
+package packet
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Ошибки разбора пакетов. Возвращаются обернутыми в *ParseError.
+var (
+	ErrFieldCount          = errors.New("неверное количество полей")
+	ErrBadSteps            = errors.New("некорректное количество шагов")
+	ErrNonPositiveSteps    = errors.New("количество шагов должно быть больше нуля")
+	ErrBadDuration         = errors.New("некорректная продолжительность")
+	ErrNonPositiveDuration = errors.New("продолжительность должна быть больше нуля")
+	ErrBadParam            = errors.New("некорректный параметр тренировки")
+	ErrBadHeartRate        = errors.New("некорректный пульс")
+	ErrUnknownActivity     = errors.New("неизвестный тип тренировки")
+)
+
+// Field — поле пакета, в котором обнаружена ошибка.
+type Field string
+
+const (
+	FieldNone      Field = ""               // ошибка относится ко всему пакету.
+	FieldSteps     Field = "шаги"           // количество шагов.
+	FieldDuration  Field = "длительность"   // продолжительность.
+	FieldActivity  Field = "тип тренировки" // тип тренировки.
+	FieldParam     Field = "параметр"       // дополнительный параметр тренировки.
+	FieldHeartRate Field = "пульс"          // средний пульс тренировки.
+)
+
+// ParseError описывает ошибку разбора пакета: исходный пакет, поле,
+// его значение и причину.
+type ParseError struct {
+	Input string // исходный пакет.
+	Field Field  // поле с ошибкой, FieldNone — весь пакет.
+	Value string // значение поля.
+	Err   error  // причина, оборачивает одну из ошибок Err*.
+}
+
+func (e *ParseError) Error() string {
+	if e.Field == FieldNone {
+		return fmt.Sprintf("пакет %q: %v", e.Input, e.Err)
+	}
+	return fmt.Sprintf("пакет %q, поле %q (%s): %v", e.Input, e.Value, e.Field, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Split делит пакет на поля и проверяет их количество: не меньше min
+// и не больше max. Отрицательный max снимает ограничение сверху.
+func Split(input string, min, max int) ([]string, error) {
+	parts := strings.Split(input, ",")
+	if len(parts) < min || (max >= 0 && len(parts) > max) {
+		want := strconv.Itoa(min)
+		if max != min {
+			want = fmt.Sprintf("от %d до %d", min, max)
+			if max < 0 {
+				want = fmt.Sprintf("не меньше %d", min)
+			}
+		}
+		return nil, &ParseError{
+			Input: input,
+			Err:   fmt.Errorf("%w: ожидалось %s, получено %d", ErrFieldCount, want, len(parts)),
+		}
+	}
+	return parts, nil
+}
+
+// Steps разбирает поле с количеством шагов. Значение должно быть
+// положительным целым числом без пробелов.
+func Steps(input, value string) (int, error) {
+	steps, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ParseError{Input: input, Field: FieldSteps, Value: value, Err: fmt.Errorf("%w: %v", ErrBadSteps, err)}
+	}
+	if steps <= 0 {
+		return 0, &ParseError{Input: input, Field: FieldSteps, Value: value, Err: ErrNonPositiveSteps}
+	}
+	return steps, nil
+}
+
+// Duration разбирает поле с продолжительностью в формате time.ParseDuration.
+// Значение должно быть положительным.
+func Duration(input, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, &ParseError{Input: input, Field: FieldDuration, Value: value, Err: fmt.Errorf("%w: %v", ErrBadDuration, err)}
+	}
+	if duration <= 0 {
+		return 0, &ParseError{Input: input, Field: FieldDuration, Value: value, Err: ErrNonPositiveDuration}
+	}
+	return duration, nil
+}
+
+// Param разбирает дополнительный числовой параметр тренировки.
+// Значение должно быть положительным.
+func Param(input, value string) (float64, error) {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, &ParseError{Input: input, Field: FieldParam, Value: value, Err: fmt.Errorf("%w: %v", ErrBadParam, err)}
+	}
+	if v <= 0 {
+		return 0, &ParseError{Input: input, Field: FieldParam, Value: value, Err: fmt.Errorf("%w: значение должно быть больше нуля", ErrBadParam)}
+	}
+	return v, nil
+}
+
+// Необязательные именованные поля пакета тренировки вида "ключ=значение".
+// Они следуют за дополнительными параметрами и не учитываются при выборе
+// варианта типа тренировки: "6000,Бег,1h00m,hr=150".
+const (
+	// OptionHeartRate — средний пульс, уд/мин, или ряд равномерно
+	// распределенных по тренировке показаний через HeartRateSeparator:
+	// "hr=150", "hr=120/135/150".
+	OptionHeartRate = "hr"
+)
+
+// HeartRateSeparator разделяет показания в ряду пульса.
+const HeartRateSeparator = "/"
+
+// Пределы допустимого пульса, уд/мин.
+const (
+	MinHeartRate = 30
+	MaxHeartRate = 250
+)
+
+// Option разбирает именованное поле пакета "ключ=значение". Для обычных
+// полей ok равно false.
+func Option(field string) (key, value string, ok bool) {
+	return strings.Cut(field, "=")
+}
+
+// HeartRate разбирает пульс в ударах в минуту. Значение должно быть
+// целым числом от MinHeartRate до MaxHeartRate.
+func HeartRate(input, value string) (int, error) {
+	hr, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, &ParseError{Input: input, Field: FieldHeartRate, Value: value, Err: fmt.Errorf("%w: %v", ErrBadHeartRate, err)}
+	}
+	if hr < MinHeartRate || hr > MaxHeartRate {
+		return 0, &ParseError{Input: input, Field: FieldHeartRate, Value: value, Err: fmt.Errorf("%w: ожидается от %d до %d уд/мин", ErrBadHeartRate, MinHeartRate, MaxHeartRate)}
+	}
+	return hr, nil
+}
+
+// HeartRates разбирает ряд показаний пульса через HeartRateSeparator.
+// Одно значение — ряд из одного показания.
+func HeartRates(input, value string) ([]int, error) {
+	samples := strings.Split(value, HeartRateSeparator)
+	series := make([]int, 0, len(samples))
+	for _, sample := range samples {
+		hr, err := HeartRate(input, sample)
+		if err != nil {
+			return nil, err
+		}
+		series = append(series, hr)
+	}
+	return series, nil
+}
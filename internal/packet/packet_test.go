@@ -0,0 +1,94 @@
+package packet
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type PacketTestSuite struct {
+	suite.Suite
+}
+
+func TestPacketSuite(t *testing.T) {
+	suite.Run(t, new(PacketTestSuite))
+}
+
+func (suite *PacketTestSuite) TestSplit() {
+	parts, err := Split("1,2,3", 3, -1)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"1", "2", "3"}, parts)
+
+	_, err = Split("1,2,3", 2, 2)
+	assert.ErrorIs(suite.T(), err, ErrFieldCount)
+	assert.EqualError(suite.T(), err, `пакет "1,2,3": неверное количество полей: ожидалось 2, получено 3`)
+
+	_, err = Split("1", 3, -1)
+	assert.EqualError(suite.T(), err, `пакет "1": неверное количество полей: ожидалось не меньше 3, получено 1`)
+}
+
+func (suite *PacketTestSuite) TestFieldErrors() {
+	tests := []struct {
+		name      string
+		parse     func() error
+		wantErr   error
+		wantField Field
+		wantValue string
+	}{
+		{
+			name:      "нечисловые шаги",
+			parse:     func() error { _, err := Steps("abc,1h", "abc"); return err },
+			wantErr:   ErrBadSteps,
+			wantField: FieldSteps,
+			wantValue: "abc",
+		},
+		{
+			name:      "нулевые шаги",
+			parse:     func() error { _, err := Steps("0,1h", "0"); return err },
+			wantErr:   ErrNonPositiveSteps,
+			wantField: FieldSteps,
+			wantValue: "0",
+		},
+		{
+			name:      "некорректная продолжительность",
+			parse:     func() error { _, err := Duration("10,1d", "1d"); return err },
+			wantErr:   ErrBadDuration,
+			wantField: FieldDuration,
+			wantValue: "1d",
+		},
+		{
+			name:      "нулевая продолжительность",
+			parse:     func() error { _, err := Duration("10,0h", "0h"); return err },
+			wantErr:   ErrNonPositiveDuration,
+			wantField: FieldDuration,
+			wantValue: "0h",
+		},
+		{
+			name:      "отрицательный параметр",
+			parse:     func() error { _, err := Param("10,Плавание,1h,-25,4", "-25"); return err },
+			wantErr:   ErrBadParam,
+			wantField: FieldParam,
+			wantValue: "-25",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			err := tt.parse()
+			assert.ErrorIs(suite.T(), err, tt.wantErr)
+
+			var pe *ParseError
+			require.True(suite.T(), errors.As(err, &pe))
+			assert.Equal(suite.T(), tt.wantField, pe.Field)
+			assert.Equal(suite.T(), tt.wantValue, pe.Value)
+		})
+	}
+
+	d, err := Duration("10,1h30m", "1h30m")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 90*time.Minute, d)
+}
@@ -0,0 +1,171 @@
+package packet
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Версии формата пакетов.
+//
+// Версия 1 — исходный формат без заголовка: "678,0h50m",
+// "3456,Ходьба,3h00m". Пакет может явно начинаться с "v1,".
+//
+// Версия 2 добавляет после номера версии метку времени:
+// "v2,2026-10-17T12:40:00+03:00,678,0h50m" или "v2,12:40:00,678,0h50m".
+// Метка может быть пустой ("v2,,678,0h50m"), тогда пакет эквивалентен
+// пакету версии 1.
+const (
+	Version1 = 1
+	Version2 = 2
+)
+
+// Ошибки разбора заголовка пакета. Возвращаются обернутыми в *ParseError.
+var (
+	ErrVersion      = errors.New("неподдерживаемая версия пакета")
+	ErrBadTimestamp = errors.New("некорректная метка времени")
+)
+
+// FieldTime — метка времени пакета.
+const FieldTime Field = "время"
+
+// stampLayouts — допустимые форматы полной метки времени. Метки без
+// часового пояса считаются местным временем.
+var stampLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// clockLayouts — допустимые форматы метки времени без даты.
+var clockLayouts = []string{
+	"15:04:05",
+	"15:04",
+}
+
+// Stamp — метка времени пакета.
+type Stamp struct {
+	// Time — момент времени. Для меток без даты (ClockOnly) задано только
+	// время суток, дата нулевая.
+	Time time.Time
+	// ClockOnly — метка содержит только время суток; дата берется
+	// из контекста (см. On).
+	ClockOnly bool
+}
+
+// IsZero сообщает, что метки времени нет.
+func (s Stamp) IsZero() bool {
+	return s.Time.IsZero() && !s.ClockOnly
+}
+
+// On размещает пакет на временной шкале: полная метка возвращается как
+// есть, время суток переносится на дату day в часовом поясе day, а без
+// метки возвращается сам day.
+func (s Stamp) On(day time.Time) time.Time {
+	switch {
+	case s.ClockOnly:
+		y, m, d := day.Date()
+		return time.Date(y, m, d, s.Time.Hour(), s.Time.Minute(), s.Time.Second(), 0, day.Location())
+	case s.Time.IsZero():
+		return day
+	default:
+		return s.Time
+	}
+}
+
+// String возвращает метку в формате RFC 3339 или "15:04:05" для метки
+// без даты. Для пустой метки возвращается пустая строка.
+func (s Stamp) String() string {
+	switch {
+	case s.ClockOnly:
+		return s.Time.Format("15:04:05")
+	case s.Time.IsZero():
+		return ""
+	default:
+		return s.Time.Format(time.RFC3339)
+	}
+}
+
+// Unstamp разбирает заголовок пакета и возвращает версию формата, метку
+// времени и тело пакета в формате версии 1. Для пакетов без заголовка
+// тело совпадает с input.
+func Unstamp(input string) (int, Stamp, string, error) {
+	version, rest, ok := strings.Cut(input, ",")
+	if !ok || len(version) < 2 || version[0] != 'v' {
+		return Version1, Stamp{}, input, nil
+	}
+
+	switch version {
+	case "v1":
+		return Version1, Stamp{}, rest, nil
+	case "v2":
+	default:
+		if !isDigits(version[1:]) {
+			// Не заголовок, а первое поле пакета версии 1.
+			return Version1, Stamp{}, input, nil
+		}
+		return 0, Stamp{}, "", &ParseError{Input: input, Err: fmt.Errorf("%w: %s", ErrVersion, version)}
+	}
+
+	value, body, ok := strings.Cut(rest, ",")
+	if !ok {
+		return 0, Stamp{}, "", &ParseError{
+			Input: input,
+			Err:   fmt.Errorf("%w: после метки времени нет данных", ErrFieldCount),
+		}
+	}
+
+	stamp, err := ParseStamp(strings.TrimSpace(value))
+	if err != nil {
+		return 0, Stamp{}, "", &ParseError{Input: input, Field: FieldTime, Value: value, Err: err}
+	}
+
+	return Version2, stamp, body, nil
+}
+
+// ParseStamp разбирает метку времени в одном из форматов версии 2.
+// Пустая строка означает пустую метку.
+func ParseStamp(value string) (Stamp, error) {
+	if value == "" {
+		return Stamp{}, nil
+	}
+
+	for _, layout := range stampLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return Stamp{Time: t}, nil
+		}
+	}
+	for _, layout := range clockLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return Stamp{Time: t, ClockOnly: true}, nil
+		}
+	}
+
+	return Stamp{}, fmt.Errorf("%w: ожидается 2006-01-02T15:04:05 (с часовым поясом или без) или 15:04:05", ErrBadTimestamp)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// WithInput заменяет в ошибке разбора исходный пакет на input. Так ошибки
+// разбора тела пакета версии 2 указывают на весь пакет вместе
+// с заголовком. Прочие ошибки возвращаются без изменений.
+func WithInput(err error, input string) error {
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		return err
+	}
+
+	withInput := *pe
+	withInput.Input = input
+	return &withInput
+}
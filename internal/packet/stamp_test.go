@@ -0,0 +1,113 @@
+package packet
+
+import (
+	"errors"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *PacketTestSuite) TestUnstamp() {
+	tests := []struct {
+		name        string
+		input       string
+		wantVersion int
+		wantStamp   Stamp
+		wantBody    string
+	}{
+		{
+			name:        "версия 1 без заголовка",
+			input:       "678,0h50m",
+			wantVersion: Version1,
+			wantBody:    "678,0h50m",
+		},
+		{
+			name:        "явная версия 1",
+			input:       "v1,678,0h50m",
+			wantVersion: Version1,
+			wantBody:    "678,0h50m",
+		},
+		{
+			name:        "полная метка",
+			input:       "v2,2026-10-17T12:40:00+03:00,3456,Ходьба,1h",
+			wantVersion: Version2,
+			wantStamp:   Stamp{Time: time.Date(2026, 10, 17, 12, 40, 0, 0, time.FixedZone("", 3*60*60))},
+			wantBody:    "3456,Ходьба,1h",
+		},
+		{
+			name:        "время суток",
+			input:       "v2, 12:40:00,3456,1h",
+			wantVersion: Version2,
+			wantStamp:   Stamp{Time: time.Date(0, 1, 1, 12, 40, 0, 0, time.UTC), ClockOnly: true},
+			wantBody:    "3456,1h",
+		},
+		{
+			name:        "пустая метка",
+			input:       "v2,,3456,1h",
+			wantVersion: Version2,
+			wantBody:    "3456,1h",
+		},
+		{
+			name:        "первое поле похоже на версию",
+			input:       "vasya,1h",
+			wantVersion: Version1,
+			wantBody:    "vasya,1h",
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			version, stamp, body, err := Unstamp(tt.input)
+			require.NoError(suite.T(), err)
+			assert.Equal(suite.T(), tt.wantVersion, version)
+			assert.True(suite.T(), tt.wantStamp.Time.Equal(stamp.Time), "метка %v, ожидается %v", stamp.Time, tt.wantStamp.Time)
+			assert.Equal(suite.T(), tt.wantStamp.ClockOnly, stamp.ClockOnly)
+			assert.Equal(suite.T(), tt.wantBody, body)
+		})
+	}
+}
+
+func (suite *PacketTestSuite) TestUnstampErrors() {
+	_, _, _, err := Unstamp("v3,12:40,3456,1h")
+	assert.ErrorIs(suite.T(), err, ErrVersion)
+
+	_, _, _, err = Unstamp("v2,12:40")
+	assert.ErrorIs(suite.T(), err, ErrFieldCount)
+
+	_, _, _, err = Unstamp("v2,вчера,3456,1h")
+	assert.ErrorIs(suite.T(), err, ErrBadTimestamp)
+
+	var pe *ParseError
+	require.True(suite.T(), errors.As(err, &pe))
+	assert.Equal(suite.T(), FieldTime, pe.Field)
+	assert.Equal(suite.T(), "вчера", pe.Value)
+}
+
+func (suite *PacketTestSuite) TestStampOn() {
+	day := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(suite.T(), day, Stamp{}.On(day))
+	assert.True(suite.T(), Stamp{}.IsZero())
+
+	clock := Stamp{Time: time.Date(0, 1, 1, 12, 40, 5, 0, time.UTC), ClockOnly: true}
+	assert.Equal(suite.T(), time.Date(2026, 10, 17, 12, 40, 5, 0, time.UTC), clock.On(day))
+	assert.Equal(suite.T(), "12:40:05", clock.String())
+
+	full := Stamp{Time: time.Date(2026, 10, 15, 7, 30, 0, 0, time.UTC)}
+	assert.Equal(suite.T(), full.Time, full.On(day))
+	assert.Equal(suite.T(), "2026-10-15T07:30:00Z", full.String())
+}
+
+func (suite *PacketTestSuite) TestWithInput() {
+	_, err := Steps("0,1h", "0")
+	err = WithInput(err, "v2,12:40,0,1h")
+
+	var pe *ParseError
+	require.True(suite.T(), errors.As(err, &pe))
+	assert.Equal(suite.T(), "v2,12:40,0,1h", pe.Input)
+	assert.ErrorIs(suite.T(), err, ErrNonPositiveSteps)
+
+	other := errors.New("другая ошибка")
+	assert.Equal(suite.T(), other, WithInput(other, "x"))
+}
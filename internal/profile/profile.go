@@ -0,0 +1,207 @@
+// Package profile описывает параметры пользователя, от которых зависят
+// расчеты дистанции и калорий.
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Yandex-Practicum/tracker/internal/heartrate"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Sex — пол пользователя.
+type Sex string
+
+const (
+	SexUnknown Sex = ""       // не указан.
+	SexMale    Sex = "male"   // мужской.
+	SexFemale  Sex = "female" // женский.
+)
+
+// Profile — параметры пользователя.
+type Profile struct {
+	Weight float64 `json:"weight"`        // вес в килограммах.
+	Height float64 `json:"height"`        // рост в метрах.
+	Age    int     `json:"age,omitempty"` // возраст в годах, 0 — не указан.
+	Sex    Sex     `json:"sex,omitempty"` // пол.
+	// StepLength — откалиброванная длина шага в метрах.
+	StepLength float64 `json:"step_length,omitempty"`
+	// Stride — модель длины шага. Если не задана, используется
+	// откалиброванная длина шага, а без неё — модель по умолчанию
+	// вызывающего пакета.
+	Stride stride.Strategy `json:"stride,omitempty"`
+	// Model — модель расчета калорий тренировок (см. spentcalories.Model).
+	// Пустое значение означает модель по умолчанию.
+	Model string `json:"model,omitempty"`
+	// Units — система единиц, в которой пользователь предпочитает видеть
+	// отчеты. Все значения профиля хранятся в метрической системе.
+	Units units.System `json:"units,omitempty"`
+	// MaxHeartRate — максимальный пульс, уд/мин. Если не задан, оценивается
+	// по возрасту (см. heartrate.EstimateMax).
+	MaxHeartRate int `json:"max_hr,omitempty"`
+	// RestingHeartRate — пульс в покое, уд/мин; нужен для зон по резерву
+	// пульса.
+	RestingHeartRate int `json:"resting_hr,omitempty"`
+	// HeartRateZones — способ расчета пульсовых зон. Пустое значение
+	// означает heartrate.MaxBased.
+	HeartRateZones heartrate.Method `json:"hr_zones,omitempty"`
+}
+
+// Validate проверяет, что параметры профиля допустимы для расчетов.
+func (p Profile) Validate() error {
+	if p.Weight <= 0 {
+		return errors.New("вес должен быть больше нуля")
+	}
+	if p.Height <= 0 {
+		return errors.New("рост должен быть больше нуля")
+	}
+	if p.Age < 0 {
+		return errors.New("возраст не может быть отрицательным")
+	}
+	if p.StepLength < 0 {
+		return errors.New("длина шага не может быть отрицательной")
+	}
+	if _, err := stride.Parse(string(p.Stride)); err != nil {
+		return err
+	}
+	if p.Stride == stride.Calibrated && p.StepLength <= 0 {
+		return errors.New("для модели calibrated нужна длина шага")
+	}
+	if _, err := units.Parse(string(p.Units)); err != nil {
+		return err
+	}
+	if p.MaxHeartRate < 0 || p.RestingHeartRate < 0 {
+		return errors.New("пульс не может быть отрицательным")
+	}
+	if p.MaxHeartRate > 0 && p.RestingHeartRate >= p.MaxHeartRate {
+		return errors.New("пульс в покое должен быть меньше максимального")
+	}
+	if _, err := heartrate.Parse(string(p.HeartRateZones)); err != nil {
+		return err
+	}
+	if p.HeartRateZones == heartrate.Reserve && p.RestingHeartRate <= 0 {
+		return errors.New("для зон по резерву пульса нужен пульс в покое")
+	}
+	switch p.Sex {
+	case SexUnknown, SexMale, SexFemale:
+	default:
+		return fmt.Errorf("неизвестный пол %q: ожидается male или female", p.Sex)
+	}
+	return nil
+}
+
+// StrideLength возвращает длину шага пользователя в метрах. def — модель
+// длины шага, которая используется, если профиль не задает её явно
+// и в нем нет откалиброванной длины шага.
+func (p Profile) StrideLength(def stride.Strategy) (float64, error) {
+	strategy := p.Stride
+	if strategy == "" {
+		strategy = def
+		if p.StepLength > 0 {
+			strategy = stride.Calibrated
+		}
+	}
+	return strategy.StepLength(p.Height, p.StepLength)
+}
+
+// Zones возвращает пульсовые зоны пользователя. Без максимального пульса
+// он оценивается по возрасту.
+func (p Profile) Zones() (heartrate.Zones, error) {
+	maxHR := p.MaxHeartRate
+	if maxHR == 0 {
+		if p.Age <= 0 {
+			return heartrate.Zones{}, errors.New("для пульсовых зон нужен максимальный пульс или возраст")
+		}
+		maxHR = heartrate.EstimateMax(p.Age)
+	}
+	return p.HeartRateZones.Zones(maxHR, p.RestingHeartRate)
+}
+
+// fileProfile — профиль в файле. Вес, рост и длина шага указываются
+// в системе единиц из поля units: в метрической — килограммы и метры,
+// в имперской — фунты и дюймы. Рост можно указать и строкой: "5'11\"".
+type fileProfile struct {
+	Weight     float64          `json:"weight"`
+	Height     json.RawMessage  `json:"height"`
+	Age        int              `json:"age"`
+	Sex        Sex              `json:"sex"`
+	StepLength float64          `json:"step_length"`
+	Stride     stride.Strategy  `json:"stride"`
+	Model      string           `json:"model"`
+	Units      units.System     `json:"units"`
+	MaxHR      int              `json:"max_hr"`
+	RestingHR  int              `json:"resting_hr"`
+	HRZones    heartrate.Method `json:"hr_zones"`
+}
+
+// Load читает профиль из JSON-файла и переводит значения в метрическую
+// систему.
+func Load(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("не удалось прочитать профиль: %w", err)
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return Profile{}, fmt.Errorf("профиль %s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Parse разбирает профиль в формате файла профиля и переводит значения
+// в метрическую систему.
+func Parse(data []byte) (Profile, error) {
+	var fp fileProfile
+	if err := json.Unmarshal(data, &fp); err != nil {
+		return Profile{}, fmt.Errorf("не удалось разобрать профиль: %w", err)
+	}
+
+	system, err := units.Parse(string(fp.Units))
+	if err != nil {
+		return Profile{}, err
+	}
+
+	height, err := parseHeight(fp.Height, system)
+	if err != nil {
+		return Profile{}, err
+	}
+
+	return Profile{
+		Weight:     system.Weight(fp.Weight),
+		Height:     height,
+		Age:        fp.Age,
+		Sex:        fp.Sex,
+		StepLength: system.Length(fp.StepLength),
+		Stride:     fp.Stride,
+		Model:      fp.Model,
+		Units:      fp.Units,
+
+		MaxHeartRate:     fp.MaxHR,
+		RestingHeartRate: fp.RestingHR,
+		HeartRateZones:   fp.HRZones,
+	}, nil
+}
+
+// parseHeight разбирает рост из файла профиля: число в единицах системы
+// или строку, понятную units.System.ParseLength.
+func parseHeight(raw json.RawMessage, system units.System) (float64, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return 0, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return system.ParseLength(s)
+	}
+
+	var v float64
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return 0, fmt.Errorf("неверный рост %s", raw)
+	}
+	return system.Length(v), nil
+}
@@ -0,0 +1,127 @@
+package profile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/heartrate"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfileTestSuite struct {
+	suite.Suite
+}
+
+func TestProfileSuite(t *testing.T) {
+	suite.Run(t, new(ProfileTestSuite))
+}
+
+func (suite *ProfileTestSuite) TestValidate() {
+	tests := []struct {
+		name    string
+		profile Profile
+		wantErr bool
+	}{
+		{name: "минимальный профиль", profile: Profile{Weight: 75, Height: 1.75}},
+		{name: "полный профиль", profile: Profile{Weight: 60, Height: 1.65, Age: 30, Sex: SexFemale, StepLength: 0.7}},
+		{name: "нулевой вес", profile: Profile{Height: 1.75}, wantErr: true},
+		{name: "нулевой рост", profile: Profile{Weight: 75}, wantErr: true},
+		{name: "отрицательный возраст", profile: Profile{Weight: 75, Height: 1.75, Age: -1}, wantErr: true},
+		{name: "отрицательная длина шага", profile: Profile{Weight: 75, Height: 1.75, StepLength: -0.5}, wantErr: true},
+		{name: "неизвестный пол", profile: Profile{Weight: 75, Height: 1.75, Sex: "x"}, wantErr: true},
+		{name: "неизвестная модель шага", profile: Profile{Weight: 75, Height: 1.75, Stride: "x"}, wantErr: true},
+		{name: "calibrated без длины шага", profile: Profile{Weight: 75, Height: 1.75, Stride: stride.Calibrated}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			err := tt.profile.Validate()
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+			} else {
+				assert.NoError(suite.T(), err)
+			}
+		})
+	}
+}
+
+func (suite *ProfileTestSuite) TestStrideLength() {
+	tests := []struct {
+		name    string
+		profile Profile
+		def     stride.Strategy
+		want    float64
+	}{
+		{name: "модель по умолчанию", profile: Profile{Height: 1.75}, def: stride.Fixed, want: 0.65},
+		{name: "откалиброванная длина шага", profile: Profile{Height: 1.75, StepLength: 0.8}, def: stride.Fixed, want: 0.8},
+		{name: "явная модель", profile: Profile{Height: 1.75, StepLength: 0.8, Stride: stride.HeightBased}, def: stride.Fixed, want: 0.7875},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := tt.profile.StrideLength(tt.def)
+			require.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.want, got, 1e-9)
+		})
+	}
+}
+
+func (suite *ProfileTestSuite) TestLoad() {
+	path := filepath.Join(suite.T().TempDir(), "profile.json")
+	data := `{"weight": 84.6, "height": 1.87, "age": 35, "sex": "male", "step_length": 0.8, "model": "met"}`
+	require.NoError(suite.T(), os.WriteFile(path, []byte(data), 0o644))
+
+	p, err := Load(path)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), Profile{Weight: 84.6, Height: 1.87, Age: 35, Sex: SexMale, StepLength: 0.8, Model: "met"}, p)
+
+	_, err = Load(filepath.Join(suite.T().TempDir(), "missing.json"))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ProfileTestSuite) TestLoadImperial() {
+	path := filepath.Join(suite.T().TempDir(), "profile.json")
+	data := `{"units": "imperial", "weight": 180, "height": "5'11\"", "step_length": 30}`
+	require.NoError(suite.T(), os.WriteFile(path, []byte(data), 0o644))
+
+	p, err := Load(path)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 81.6466, p.Weight, 1e-4)
+	assert.InDelta(suite.T(), 1.8034, p.Height, 1e-4)
+	assert.InDelta(suite.T(), 0.762, p.StepLength, 1e-9)
+	assert.Equal(suite.T(), units.Imperial, p.Units)
+
+	require.NoError(suite.T(), os.WriteFile(path, []byte(`{"units": "imperial", "weight": 180, "height": 71}`), 0o644))
+	p, err = Load(path)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 1.8034, p.Height, 1e-4)
+
+	require.NoError(suite.T(), os.WriteFile(path, []byte(`{"units": "nautical", "weight": 180}`), 0o644))
+	_, err = Load(path)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ProfileTestSuite) TestZones() {
+	z, err := Profile{Age: 30}.Zones()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), heartrate.Zone{Number: 5, Low: 171, High: 190}, z[4])
+
+	z, err = Profile{Age: 30, MaxHeartRate: 200, RestingHeartRate: 50, HeartRateZones: heartrate.Reserve}.Zones()
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), heartrate.Zone{Number: 1, Low: 125, High: 140}, z[0])
+
+	_, err = Profile{}.Zones()
+	assert.Error(suite.T(), err)
+
+	p := Profile{Weight: 75, Height: 1.75, HeartRateZones: heartrate.Reserve}
+	assert.Error(suite.T(), p.Validate(), "reserve без пульса в покое")
+	p.RestingHeartRate, p.MaxHeartRate = 190, 180
+	assert.Error(suite.T(), p.Validate(), "пульс в покое выше максимального")
+	p.RestingHeartRate = 60
+	assert.NoError(suite.T(), p.Validate())
+}
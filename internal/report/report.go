@@ -0,0 +1,70 @@
+// Package report выводит отчеты трекера в текстовом виде или в JSON.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+)
+
+// Format — формат вывода отчетов.
+type Format string
+
+const (
+	FormatText Format = "text" // текст, как возвращают DayActionInfo и TrainingInfo.
+	FormatJSON Format = "json" // JSON Lines: по одному объекту на строку.
+)
+
+// ParseFormat возвращает формат по его названию. Пустая строка означает FormatText.
+func ParseFormat(s string) (Format, error) {
+	switch Format(s) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatJSON:
+		return FormatJSON, nil
+	default:
+		return "", fmt.Errorf("неизвестный формат вывода %q: ожидается text или json", s)
+	}
+}
+
+// Report — отчет, который можно вывести в обоих форматах: текст берется
+// из Format, JSON — из MarshalJSON.
+type Report interface {
+	Format(loc i18n.Locale) string
+	json.Marshaler
+}
+
+// Encoder последовательно выводит отчеты в выбранном формате.
+type Encoder struct {
+	w      io.Writer
+	format Format
+	locale i18n.Locale
+	json   *json.Encoder
+}
+
+// NewEncoder создает Encoder, который пишет отчеты в w.
+func NewEncoder(w io.Writer, format Format) *Encoder {
+	e := &Encoder{w: w, format: format}
+	if format == FormatJSON {
+		e.json = json.NewEncoder(w)
+		e.json.SetEscapeHTML(false)
+	}
+	return e
+}
+
+// SetLocale задает язык и единицы измерения текстовых отчетов.
+// JSON всегда выводится в метрических единицах, указанных в названиях полей.
+func (e *Encoder) SetLocale(loc i18n.Locale) {
+	e.locale = loc
+}
+
+// Encode выводит один отчет. Текстовые отчеты разделяются пустой строкой.
+func (e *Encoder) Encode(r Report) error {
+	if e.json != nil {
+		return e.json.Encode(r)
+	}
+	_, err := fmt.Fprintln(e.w, r.Format(e.locale))
+	return err
+}
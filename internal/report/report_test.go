@@ -0,0 +1,74 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReportTestSuite struct {
+	suite.Suite
+}
+
+func TestReportSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
+
+func (suite *ReportTestSuite) TestEncodeJSON() {
+	var buf bytes.Buffer
+	enc := report.NewEncoder(&buf, report.FormatJSON)
+
+	require.NoError(suite.T(), enc.Encode(daysteps.DayAction{
+		Steps:    6000,
+		Duration: time.Hour,
+		Distance: 3.9,
+		Calories: 177.1875,
+	}))
+	require.NoError(suite.T(), enc.Encode(spentcalories.TrainingSummary{
+		Activity: "Бег",
+		Steps:    6000,
+		Duration: 30 * time.Minute,
+		Distance: 4.725,
+		Speed:    9.45,
+		Calories: 354.375,
+	}))
+
+	assert.Equal(suite.T(),
+		`{"steps":6000,"duration_s":3600,"distance_km":3.9,"calories_kcal":177.1875}`+"\n"+
+			`{"activity":"Бег","steps":6000,"duration_s":1800,"distance_km":4.725,"speed_kmh":9.45,"calories_kcal":354.375}`+"\n",
+		buf.String())
+}
+
+func (suite *ReportTestSuite) TestEncodeText() {
+	var buf bytes.Buffer
+	action := daysteps.DayAction{Steps: 6000, Duration: time.Hour, Distance: 3.9, Calories: 177.1875}
+
+	require.NoError(suite.T(), report.NewEncoder(&buf, report.FormatText).Encode(action))
+	assert.Equal(suite.T(), action.String()+"\n", buf.String())
+}
+
+func (suite *ReportTestSuite) TestParseFormat() {
+	f, err := report.ParseFormat("")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), report.FormatText, f)
+
+	_, err = report.ParseFormat("xml")
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportTestSuite) TestEncodeTextLanguage() {
+	var buf bytes.Buffer
+	enc := report.NewEncoder(&buf, report.FormatText)
+	enc.SetLocale(i18n.Locale{Lang: i18n.English})
+
+	require.NoError(suite.T(), enc.Encode(daysteps.DayAction{Steps: 6000, Duration: time.Hour, Distance: 3.9, Calories: 177.1875}))
+	assert.Equal(suite.T(), "Steps: 6000.\nDistance: 3.90 km.\nCalories burned: 177.19 kcal.\n\n", buf.String())
+}
@@ -0,0 +1,265 @@
+// Package server предоставляет HTTP API для расчетов дневной активности
+// и тренировок.
+//
+// Эндпоинты принимают POST-запросы:
+//
+//	POST /v1/day       — пакет "шаги,длительность";
+//	POST /v1/training  — пакет "шаги,тип,длительность[,параметры]".
+//
+// Тело запроса — либо сам пакет (text/plain), либо JSON вида
+// {"packet": "...", "profile": {...}}. Профиль в запросе задается в формате
+// файла профиля, в том числе с полем units, и заменяет профиль сервера
+// по умолчанию. Ответ — JSON-отчет в том же виде, что выводит
+// report.Encoder, или ошибка {"error": {"code": ..., "message": ...}}.
+// Ошибкой в том же виде отвечают и неизвестные пути (404), и запросы
+// с другим методом (405).
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Yandex-Practicum/tracker/internal/daysteps"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+	"github.com/Yandex-Practicum/tracker/internal/spentcalories"
+)
+
+// DefaultMaxBodySize — максимальный размер тела запроса по умолчанию.
+const DefaultMaxBodySize = 64 * 1024
+
+// Коды ошибок в ответах API.
+const (
+	CodeBadRequest          = "bad_request"           // тело запроса не разобрано.
+	CodeBodyTooLarge        = "body_too_large"        // тело запроса больше MaxBodySize.
+	CodeUnsupportedMedia    = "unsupported_media"     // неподдерживаемый Content-Type.
+	CodeProfileRequired     = "profile_required"      // профиль не задан ни в запросе, ни на сервере.
+	CodeInvalidProfile      = "invalid_profile"       // профиль не прошел проверку.
+	CodeFieldCount          = "field_count"           // packet.ErrFieldCount.
+	CodeBadSteps            = "bad_steps"             // packet.ErrBadSteps.
+	CodeNonPositiveSteps    = "non_positive_steps"    // packet.ErrNonPositiveSteps.
+	CodeBadDuration         = "bad_duration"          // packet.ErrBadDuration.
+	CodeNonPositiveDuration = "non_positive_duration" // packet.ErrNonPositiveDuration.
+	CodeBadParam            = "bad_param"             // packet.ErrBadParam.
+	CodeUnknownActivity     = "unknown_activity"      // packet.ErrUnknownActivity.
+	CodeBadHeartRate        = "bad_heart_rate"        // packet.ErrBadHeartRate.
+	CodeUnsupportedVersion  = "unsupported_version"   // packet.ErrVersion.
+	CodeBadTimestamp        = "bad_timestamp"         // packet.ErrBadTimestamp.
+	CodeCalculation         = "calculation_failed"    // прочие ошибки расчета.
+	CodeNotFound            = "not_found"             // неизвестный путь.
+	CodeMethodNotAllowed    = "method_not_allowed"    // метод не поддерживается эндпоинтом.
+)
+
+// packetCodes сопоставляет ошибкам разбора пакетов коды API.
+var packetCodes = []struct {
+	err  error
+	code string
+}{
+	{packet.ErrFieldCount, CodeFieldCount},
+	{packet.ErrBadSteps, CodeBadSteps},
+	{packet.ErrNonPositiveSteps, CodeNonPositiveSteps},
+	{packet.ErrBadDuration, CodeBadDuration},
+	{packet.ErrNonPositiveDuration, CodeNonPositiveDuration},
+	{packet.ErrBadParam, CodeBadParam},
+	{packet.ErrUnknownActivity, CodeUnknownActivity},
+	{packet.ErrBadHeartRate, CodeBadHeartRate},
+	{packet.ErrVersion, CodeUnsupportedVersion},
+	{packet.ErrBadTimestamp, CodeBadTimestamp},
+}
+
+// fieldKeys сопоставляет полям пакета стабильные ключи для Error.Field:
+// packet.Field — слова для отчетов, и они могут меняться.
+var fieldKeys = map[packet.Field]string{
+	packet.FieldSteps:     "steps",
+	packet.FieldDuration:  "duration",
+	packet.FieldActivity:  "activity",
+	packet.FieldParam:     "param",
+	packet.FieldHeartRate: "heart_rate",
+	packet.FieldTime:      "time",
+}
+
+// Server обслуживает HTTP API.
+type Server struct {
+	// Profile — профиль по умолчанию. Если nil, профиль обязателен
+	// в каждом запросе.
+	Profile     *profile.Profile
+	MaxBodySize int64 // если 0, используется DefaultMaxBodySize.
+}
+
+// Handler возвращает http.Handler с эндпоинтами API.
+func (s *Server) Handler() http.Handler {
+	routes := []struct {
+		path string
+		calc func(data string, p profile.Profile) (report.Report, error)
+	}{
+		{"/v1/day", func(data string, p profile.Profile) (report.Report, error) {
+			return daysteps.CalculateFor(data, p)
+		}},
+		{"/v1/training", func(data string, p profile.Profile) (report.Report, error) {
+			return spentcalories.CalculateFor(data, p)
+		}},
+	}
+
+	mux := http.NewServeMux()
+	for _, route := range routes {
+		mux.HandleFunc("POST "+route.path, s.calculate(route.calc))
+		// Остальные методы попадают сюда: шаблон без метода менее точный.
+		mux.HandleFunc(route.path, methodNotAllowed)
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, CodeNotFound, "неизвестный путь "+r.URL.Path)
+	})
+	return mux
+}
+
+func methodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Allow", http.MethodPost)
+	writeError(w, http.StatusMethodNotAllowed, CodeMethodNotAllowed, "метод "+r.Method+" не поддерживается, ожидается POST")
+}
+
+// Request — тело JSON-запроса.
+type Request struct {
+	Packet string `json:"packet"`
+	// Profile — профиль в формате файла профиля (см. profile.Parse),
+	// в том числе с полем units.
+	Profile json.RawMessage `json:"profile,omitempty"`
+}
+
+// Error — описание ошибки в ответе API.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// Field — поле пакета для ошибок разбора: steps, duration, activity,
+	// param, heart_rate или time.
+	Field string `json:"field,omitempty"`
+	Value string `json:"value,omitempty"` // значение поля.
+}
+
+type errorResponse struct {
+	Error Error `json:"error"`
+}
+
+func (s *Server) calculate(calc func(data string, p profile.Profile) (report.Report, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		req, status, apiErr := s.decode(w, r)
+		if apiErr != nil {
+			writeJSON(w, status, errorResponse{Error: *apiErr})
+			return
+		}
+
+		p := s.Profile
+		if len(req.Profile) > 0 && string(req.Profile) != "null" {
+			parsed, err := profile.Parse(req.Profile)
+			if err != nil {
+				writeError(w, http.StatusUnprocessableEntity, CodeInvalidProfile, err.Error())
+				return
+			}
+			p = &parsed
+		}
+		if p == nil {
+			writeError(w, http.StatusUnprocessableEntity, CodeProfileRequired, "укажите профиль в запросе")
+			return
+		}
+		if err := validateProfile(*p); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, CodeInvalidProfile, err.Error())
+			return
+		}
+
+		rep, err := calc(strings.TrimSpace(req.Packet), *p)
+		if err != nil {
+			writeJSON(w, http.StatusUnprocessableEntity, errorResponse{Error: calculationError(err)})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, rep)
+	}
+}
+
+// validateProfile проверяет профиль и модель расчета калорий в нем:
+// неизвестная модель — ошибка профиля, а не расчета.
+func validateProfile(p profile.Profile) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+	_, err := spentcalories.ParseModel(p.Model)
+	return err
+}
+
+// decode читает тело запроса. При ошибке возвращает HTTP-статус и описание.
+func (s *Server) decode(w http.ResponseWriter, r *http.Request) (Request, int, *Error) {
+	limit := s.MaxBodySize
+	if limit <= 0 {
+		limit = DefaultMaxBodySize
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, limit))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return Request{}, http.StatusRequestEntityTooLarge, &Error{Code: CodeBodyTooLarge, Message: err.Error()}
+		}
+		return Request{}, http.StatusBadRequest, &Error{Code: CodeBadRequest, Message: err.Error()}
+	}
+
+	mediaType := "text/plain"
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if mediaType, _, err = mime.ParseMediaType(ct); err != nil {
+			return Request{}, http.StatusUnsupportedMediaType, &Error{Code: CodeUnsupportedMedia, Message: err.Error()}
+		}
+	}
+
+	switch mediaType {
+	case "text/plain":
+		return Request{Packet: string(body)}, 0, nil
+	case "application/json":
+		var req Request
+		if err := json.Unmarshal(body, &req); err != nil {
+			return Request{}, http.StatusBadRequest, &Error{Code: CodeBadRequest, Message: "неверный JSON: " + err.Error()}
+		}
+		return req, 0, nil
+	default:
+		return Request{}, http.StatusUnsupportedMediaType, &Error{
+			Code:    CodeUnsupportedMedia,
+			Message: "ожидается text/plain или application/json, получено " + mediaType,
+		}
+	}
+}
+
+// calculationError описывает ошибку расчета: для ошибок разбора пакета
+// указываются код, поле и значение.
+func calculationError(err error) Error {
+	e := Error{Code: CodeCalculation, Message: err.Error()}
+
+	for _, pc := range packetCodes {
+		if errors.Is(err, pc.err) {
+			e.Code = pc.code
+			break
+		}
+	}
+
+	var pe *packet.ParseError
+	if errors.As(err, &pe) {
+		e.Field = fieldKeys[pe.Field]
+		e.Value = pe.Value
+	}
+
+	return e
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, errorResponse{Error: Error{Code: code, Message: message}})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+}
@@ -0,0 +1,207 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServerTestSuite struct {
+	suite.Suite
+	handler http.Handler
+}
+
+func TestServerSuite(t *testing.T) {
+	suite.Run(t, new(ServerTestSuite))
+}
+
+func (suite *ServerTestSuite) SetupTest() {
+	s := &Server{Profile: &profile.Profile{Weight: 84.6, Height: 1.87}}
+	suite.handler = s.Handler()
+}
+
+func (suite *ServerTestSuite) do(method, path, contentType, body string) (*httptest.ResponseRecorder, map[string]any) {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	suite.handler.ServeHTTP(rec, req)
+
+	var resp map[string]any
+	if strings.HasPrefix(rec.Header().Get("Content-Type"), "application/json") {
+		require.NoError(suite.T(), json.Unmarshal(rec.Body.Bytes(), &resp))
+	}
+	return rec, resp
+}
+
+func (suite *ServerTestSuite) errorCode(resp map[string]any) string {
+	e, ok := resp["error"].(map[string]any)
+	require.True(suite.T(), ok, "в ответе нет ошибки: %v", resp)
+	code, _ := e["code"].(string)
+	return code
+}
+
+func (suite *ServerTestSuite) TestDayPlain() {
+	rec, resp := suite.do(http.MethodPost, "/v1/day", "text/plain", "678,0h50m\n")
+	require.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), float64(678), resp["steps"])
+	assert.Equal(suite.T(), float64(3000), resp["duration_s"])
+}
+
+func (suite *ServerTestSuite) TestTrainingJSON() {
+	body := `{"packet": "6000,Бег,1h00m", "profile": {"weight": 70, "height": 1.75}}`
+	rec, resp := suite.do(http.MethodPost, "/v1/training", "application/json", body)
+	require.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "Бег", resp["activity"])
+	assert.InDelta(suite.T(), 330.75, resp["calories_kcal"], 0.01)
+}
+
+func (suite *ServerTestSuite) TestImperialProfile() {
+	_, metric := suite.do(http.MethodPost, "/v1/training", "application/json",
+		`{"packet": "6000,Бег,1h00m", "profile": {"weight": 81.6466266, "height": 1.778}}`)
+	rec, resp := suite.do(http.MethodPost, "/v1/training", "application/json",
+		`{"packet": "6000,Бег,1h00m", "profile": {"units": "imperial", "weight": 180, "height": "5'10\""}}`)
+	require.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.InDelta(suite.T(), metric["distance_km"], resp["distance_km"], 1e-6)
+	assert.InDelta(suite.T(), metric["calories_kcal"], resp["calories_kcal"], 1e-6)
+
+	rec, resp = suite.do(http.MethodPost, "/v1/training", "application/json",
+		`{"packet": "6000,Бег,1h00m", "profile": {"units": "nautical", "weight": 180, "height": 70}}`)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(suite.T(), CodeInvalidProfile, suite.errorCode(resp))
+}
+
+func (suite *ServerTestSuite) TestPacketErrors() {
+	tests := []struct {
+		path  string
+		body  string
+		code  string
+		field string
+	}{
+		{"/v1/day", "678", CodeFieldCount, ""},
+		{"/v1/day", ",0h50m", CodeBadSteps, "steps"},
+		{"/v1/day", "0,0h50m", CodeNonPositiveSteps, "steps"},
+		{"/v1/training", "6000,Бег,час", CodeBadDuration, "duration"},
+		{"/v1/training", "6000,Танцы,1h00m", CodeUnknownActivity, "activity"},
+		{"/v1/training", "1200,Плавание,1h00m,x,40", CodeBadParam, "param"},
+		{"/v1/training", "6000,Бег,1h00m,hr=20", CodeBadHeartRate, "heart_rate"},
+		{"/v1/training", "v2,xx,6000,Бег,1h00m", CodeBadTimestamp, "time"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.body, func() {
+			rec, resp := suite.do(http.MethodPost, tt.path, "", tt.body)
+			assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(suite.T(), tt.code, suite.errorCode(resp))
+			e := resp["error"].(map[string]any)
+			if tt.field != "" {
+				assert.Equal(suite.T(), tt.field, e["field"])
+			} else {
+				assert.NotContains(suite.T(), e, "field")
+			}
+		})
+	}
+}
+
+func (suite *ServerTestSuite) TestRequestErrors() {
+	rec, resp := suite.do(http.MethodPost, "/v1/day", "application/json", "{")
+	assert.Equal(suite.T(), http.StatusBadRequest, rec.Code)
+	assert.Equal(suite.T(), CodeBadRequest, suite.errorCode(resp))
+
+	rec, resp = suite.do(http.MethodPost, "/v1/day", "application/xml", "<packet/>")
+	assert.Equal(suite.T(), http.StatusUnsupportedMediaType, rec.Code)
+	assert.Equal(suite.T(), CodeUnsupportedMedia, suite.errorCode(resp))
+
+	rec, resp = suite.do(http.MethodPost, "/v1/day", "application/json", `{"packet": "678,0h50m", "profile": {"weight": 70}}`)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(suite.T(), CodeInvalidProfile, suite.errorCode(resp))
+
+	rec, resp = suite.do(http.MethodPost, "/v1/training", "application/json",
+		`{"packet": "6000,Бег,1h00m", "profile": {"weight": 70, "height": 1.75, "model": "bogus"}}`)
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(suite.T(), CodeInvalidProfile, suite.errorCode(resp))
+
+	rec, resp = suite.do(http.MethodGet, "/v1/day", "", "")
+	assert.Equal(suite.T(), http.StatusMethodNotAllowed, rec.Code)
+	assert.Equal(suite.T(), CodeMethodNotAllowed, suite.errorCode(resp))
+	assert.Equal(suite.T(), http.MethodPost, rec.Header().Get("Allow"))
+
+	for _, path := range []string{"/", "/v1/days", "/v1/day/extra"} {
+		rec, resp = suite.do(http.MethodPost, path, "", "678,0h50m")
+		assert.Equal(suite.T(), http.StatusNotFound, rec.Code, path)
+		assert.Equal(suite.T(), CodeNotFound, suite.errorCode(resp), path)
+	}
+}
+
+func (suite *ServerTestSuite) TestBodyTooLarge() {
+	s := &Server{Profile: &profile.Profile{Weight: 84.6, Height: 1.87}, MaxBodySize: 8}
+	suite.handler = s.Handler()
+
+	rec, resp := suite.do(http.MethodPost, "/v1/day", "", "678,0h50m")
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Equal(suite.T(), CodeBodyTooLarge, suite.errorCode(resp))
+}
+
+func (suite *ServerTestSuite) TestProfileRequired() {
+	suite.handler = (&Server{}).Handler()
+
+	rec, resp := suite.do(http.MethodPost, "/v1/day", "", "678,0h50m")
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(suite.T(), CodeProfileRequired, suite.errorCode(resp))
+}
+
+func (suite *ServerTestSuite) TestHeartRateErrors() {
+	for _, body := range []string{"6000,Бег,1h,hr=20", "6000,Бег,1h,hr=abc", "6000,Бег,1h,hr=150,hr=160"} {
+		suite.Run(body, func() {
+			rec, resp := suite.do(http.MethodPost, "/v1/training", "", body)
+			assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(suite.T(), CodeBadHeartRate, suite.errorCode(resp))
+		})
+	}
+
+	rec, resp := suite.do(http.MethodPost, "/v1/training", "", "6000,Бег,1h,hr=150")
+	require.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), float64(150), resp["heart_rate_bpm"])
+}
+
+func (suite *ServerTestSuite) TestHeaderErrors() {
+	tests := []struct {
+		path string
+		body string
+		code string
+	}{
+		{"/v1/training", "v9,,6000,Бег,1h", CodeUnsupportedVersion},
+		{"/v1/day", "v9,,678,0h50m", CodeUnsupportedVersion},
+		{"/v1/training", "v2,xx,6000,Бег,1h", CodeBadTimestamp},
+		{"/v1/day", "v2,25:99,678,0h50m", CodeBadTimestamp},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.body, func() {
+			rec, resp := suite.do(http.MethodPost, tt.path, "", tt.body)
+			assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(suite.T(), tt.code, suite.errorCode(resp))
+		})
+	}
+
+	rec, resp := suite.do(http.MethodPost, "/v1/training", "", "v2,12:40:00,6000,Бег,1h")
+	require.Equal(suite.T(), http.StatusOK, rec.Code)
+	assert.Equal(suite.T(), "12:40:00", resp["time"])
+}
+
+func (suite *ServerTestSuite) TestFractionalLaps() {
+	rec, resp := suite.do(http.MethodPost, "/v1/training", "", "1200,Плавание,1h00m,25,40.5")
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(suite.T(), CodeBadParam, suite.errorCode(resp))
+	e := resp["error"].(map[string]any)
+	assert.Equal(suite.T(), "param", e["field"])
+	assert.Equal(suite.T(), "40.5", e["value"])
+}
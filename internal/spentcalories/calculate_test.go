@@ -0,0 +1,68 @@
+package spentcalories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *SpentCaloriesTestSuite) TestCalculate() {
+	got, err := Calculate("6000,Бег,1h00m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), TrainingSummary{
+		Activity: "Бег",
+		Steps:    6000,
+		Duration: time.Hour,
+		Distance: 4.725,
+		Speed:    4.725,
+		Calories: 354.375,
+	}, got)
+
+	_, err = Calculate("6000,Плавание,1h00m", 75.0, 1.75)
+	assert.True(suite.T(), errors.Is(err, ErrUnknownTraining))
+}
+
+func (suite *SpentCaloriesTestSuite) TestCalculateParseErrors() {
+	tests := []struct {
+		name      string
+		input     string
+		wantErr   error
+		wantField packet.Field
+	}{
+		{name: "мало полей", input: "6000,Бег", wantErr: packet.ErrFieldCount},
+		{name: "нечисловые шаги", input: "abc,Бег,1h", wantErr: packet.ErrBadSteps, wantField: packet.FieldSteps},
+		{name: "ноль шагов", input: "0,Бег,1h", wantErr: packet.ErrNonPositiveSteps, wantField: packet.FieldSteps},
+		{name: "продолжительность", input: "6000,Бег,1d", wantErr: packet.ErrBadDuration, wantField: packet.FieldDuration},
+		{name: "неизвестный тип", input: "6000,Йога,1h", wantErr: packet.ErrUnknownActivity, wantField: packet.FieldActivity},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, err := Calculate(tt.input, 75.0, 1.75)
+			assert.ErrorIs(suite.T(), err, tt.wantErr)
+
+			var pe *packet.ParseError
+			require.True(suite.T(), errors.As(err, &pe))
+			assert.Equal(suite.T(), tt.input, pe.Input)
+			assert.Equal(suite.T(), tt.wantField, pe.Field)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestCalculateLocalized() {
+	got, err := Calculate("6000,Running,1h00m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Бег", got.Activity)
+
+	assert.Equal(suite.T(),
+		"Training type: Running\nDuration: 1.00 h.\nDistance: 4.72 km.\nSpeed: 4.72 km/h\nCalories burned: 354.38\n",
+		got.Format(i18n.Locale{Lang: i18n.English}))
+
+	_, err = Calculate("1200,Swimming,1h00m,25,40", 75.0, 1.75)
+	assert.NoError(suite.T(), err)
+}
@@ -0,0 +1,178 @@
+package spentcalories
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
+)
+
+// ActivityConfig — описание собственного типа тренировки по шагам в файле
+// настроек. Дистанция считается по длине шага, калории — по формуле бега
+// и ходьбы: вес * скорость * часы * CaloriesCoefficient.
+type ActivityConfig struct {
+	// Name — основное название типа, как в пакетах данных.
+	Name string `yaml:"name"`
+	// Aliases — дополнительные названия типа в пакетах.
+	Aliases []string `yaml:"aliases"`
+	// Translations — названия типа в отчетах по кодам языков.
+	Translations map[string]string `yaml:"translations"`
+	// StepLengthFactor — длина шага в долях роста. Если 0, используется
+	// stride.HeightCoefficient. Модель длины шага из профиля имеет приоритет.
+	StepLengthFactor float64 `yaml:"step_length_factor"`
+	// CaloriesCoefficient — коэффициент формулы калорий: 1 у бега,
+	// 0,5 у ходьбы.
+	CaloriesCoefficient float64 `yaml:"calories_coefficient"`
+	// MET — значение MET для модели ModelMET. Если 0, тип поддерживает
+	// только ModelLegacy.
+	MET float64 `yaml:"met"`
+	// Pace — выводить для типа темп и сплиты.
+	Pace bool `yaml:"pace"`
+}
+
+// Config — файл настроек с собственными типами тренировок.
+type Config struct {
+	Activities []ActivityConfig `yaml:"activities"`
+}
+
+// LoadConfig читает файл настроек в формате YAML или JSON.
+func LoadConfig(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("не удалось прочитать типы тренировок: %w", err)
+	}
+
+	var c Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("не удалось разобрать типы тренировок %s: %w", path, err)
+	}
+
+	return c, nil
+}
+
+// Validate проверяет описание типа тренировки.
+func (c ActivityConfig) Validate() error {
+	if c.Name == "" {
+		return errors.New("у типа тренировки должно быть название")
+	}
+	if c.StepLengthFactor < 0 {
+		return fmt.Errorf("тип тренировки %q: коэффициент длины шага не может быть отрицательным", c.Name)
+	}
+	if c.CaloriesCoefficient <= 0 {
+		return fmt.Errorf("тип тренировки %q: коэффициент калорий должен быть больше нуля", c.Name)
+	}
+	if c.MET < 0 {
+		return fmt.Errorf("тип тренировки %q: MET не может быть отрицательным", c.Name)
+	}
+	return nil
+}
+
+// Activity возвращает тип тренировки для реестра.
+func (c ActivityConfig) Activity() (Activity, error) {
+	if err := c.Validate(); err != nil {
+		return Activity{}, err
+	}
+
+	a := Activity{
+		Names:    append([]string{c.Name}, c.Aliases...),
+		Distance: c.distance,
+		Calories: c.calories,
+		Pace:     c.Pace,
+		Steps:    true,
+	}
+	if c.MET > 0 {
+		a.Intensity = func(float64) float64 { return c.MET }
+	}
+	return a, nil
+}
+
+// distance считает дистанцию по длине шага: рост * StepLengthFactor,
+// если профиль не задает длину шага явно.
+func (c ActivityConfig) distance(w Workout) float64 {
+	p := w.Profile
+	if p.Stride == "" && p.StepLength <= 0 {
+		factor := c.StepLengthFactor
+		if factor == 0 {
+			factor = stride.HeightCoefficient
+		}
+		return stride.Distance(w.Steps, p.Height*factor)
+	}
+
+	length, err := p.StrideLength(stride.HeightBased)
+	if err != nil {
+		return 0
+	}
+	return stride.Distance(w.Steps, length)
+}
+
+func (c ActivityConfig) calories(w Workout) (float64, error) {
+	if err := validate(w); err != nil {
+		return 0, err
+	}
+
+	speed := c.distance(w) / w.Duration.Hours()
+	return w.Profile.Weight * speed * w.Duration.Minutes() / minInH * c.CaloriesCoefficient, nil
+}
+
+// RegisterConfig регистрирует типы тренировок из файла настроек и их
+// переводы. Названия и переводы не должны совпадать с уже известными
+// названиями и переводами, иначе пакеты встроенных типов могли бы
+// распознаваться как новые. Если хотя бы одно описание неверно, реестр
+// и каталоги не меняются.
+func RegisterConfig(c Config) error {
+	activities := make([]Activity, 0, len(c.Activities))
+	owners := make(map[string]string) // название или перевод → основное название типа.
+	claim := func(name, owner string) error {
+		if name == "" {
+			return fmt.Errorf("тип тренировки %q: пустое название или перевод", owner)
+		}
+		if prev, ok := owners[name]; ok && prev != owner {
+			return fmt.Errorf("название %q указано и у типа %q, и у типа %q", name, prev, owner)
+		}
+		if isRegistered(name) {
+			return fmt.Errorf("тип тренировки %q уже зарегистрирован", name)
+		}
+		if canonical, ok := i18n.CanonicalActivity(name); ok {
+			return fmt.Errorf("название %q уже используется как перевод типа %q", name, canonical)
+		}
+		owners[name] = owner
+		return nil
+	}
+
+	for _, ac := range c.Activities {
+		a, err := ac.Activity()
+		if err != nil {
+			return err
+		}
+		for _, name := range a.Names {
+			if err := claim(name, ac.Name); err != nil {
+				return err
+			}
+		}
+		for _, tr := range ac.Translations {
+			if err := claim(tr, ac.Name); err != nil {
+				return err
+			}
+		}
+		activities = append(activities, a)
+	}
+
+	for i, a := range activities {
+		if err := Register(a); err != nil {
+			return err
+		}
+		for lang, name := range c.Activities[i].Translations {
+			i18n.Register(i18n.Lang(lang), i18n.Catalog{i18n.ActivityKey(a.Names[0]): name})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,139 @@
+package spentcalories
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+)
+
+// unregisterConfig удаляет из реестра и каталогов переводов типы
+// тренировок, зарегистрированные из c.
+func unregisterConfig(c Config) {
+	for _, ac := range c.Activities {
+		unregister(append([]string{ac.Name}, ac.Aliases...)...)
+		for lang := range ac.Translations {
+			i18n.Unregister(i18n.Lang(lang), i18n.ActivityKey(ac.Name))
+		}
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestLoadConfig() {
+	path := filepath.Join(suite.T().TempDir(), "activities.yaml")
+	err := os.WriteFile(path, []byte(`activities:
+  - name: Скандинавская ходьба
+    aliases: [Nordic]
+    translations:
+      en: Nordic walking
+    step_length_factor: 0.5
+    calories_coefficient: 0.8
+`), 0o644)
+	require.NoError(suite.T(), err)
+
+	c, err := LoadConfig(path)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), RegisterConfig(c))
+	suite.T().Cleanup(func() { unregisterConfig(c) })
+
+	got, err := TrainingInfo("6000,Скандинавская ходьба,1h00m", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Скандинавская ходьба\nДлительность: 1.00 ч.\nДистанция: 5.25 км.\nСкорость: 5.25 км/ч\nСожгли калорий: 315.00\n", got)
+
+	s, err := Calculate("6000,Nordic walking,1h00m", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Скандинавская ходьба", s.Activity)
+
+	_, err = Calculate("6000,Nordic,1h00m", 75, 1.75)
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestLoadConfigJSON() {
+	path := filepath.Join(suite.T().TempDir(), "activities.json")
+	err := os.WriteFile(path, []byte(`{"activities": [{"name": "Трекинг", "calories_coefficient": 0.7, "met": 6}]}`), 0o644)
+	require.NoError(suite.T(), err)
+
+	c, err := LoadConfig(path)
+	require.NoError(suite.T(), err)
+	require.Len(suite.T(), c.Activities, 1)
+	assert.Equal(suite.T(), 6.0, c.Activities[0].MET)
+
+	a, err := c.Activities[0].Activity()
+	require.NoError(suite.T(), err)
+	require.NotNil(suite.T(), a.Intensity)
+	assert.Equal(suite.T(), 6.0, a.Intensity(4))
+}
+
+func (suite *SpentCaloriesTestSuite) TestConfigErrors() {
+	dir := suite.T().TempDir()
+	path := filepath.Join(dir, "activities.yaml")
+	require.NoError(suite.T(), os.WriteFile(path, []byte("activities:\n  - name: Йога\n    coefficient: 1\n"), 0o644))
+
+	_, err := LoadConfig(path)
+	assert.Error(suite.T(), err, "неизвестное поле")
+
+	_, err = LoadConfig(filepath.Join(dir, "missing.yaml"))
+	assert.ErrorIs(suite.T(), err, os.ErrNotExist)
+
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "без названия", config: Config{Activities: []ActivityConfig{{CaloriesCoefficient: 1}}}},
+		{name: "без коэффициента калорий", config: Config{Activities: []ActivityConfig{{Name: "Йога"}}}},
+		{name: "отрицательная длина шага", config: Config{Activities: []ActivityConfig{{Name: "Йога", CaloriesCoefficient: 1, StepLengthFactor: -1}}}},
+		{name: "встроенный тип", config: Config{Activities: []ActivityConfig{{Name: "Йога", CaloriesCoefficient: 1}, {Name: "Бег", CaloriesCoefficient: 1}}}},
+		{name: "повтор в файле", config: Config{Activities: []ActivityConfig{{Name: "Йога", CaloriesCoefficient: 1}, {Name: "Пилатес", Aliases: []string{"Йога"}, CaloriesCoefficient: 1}}}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Error(suite.T(), RegisterConfig(tt.config))
+		})
+	}
+
+	_, ok := Lookup("Йога", 0)
+	assert.False(suite.T(), ok)
+}
+
+func (suite *SpentCaloriesTestSuite) TestConfigNameCollisions() {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{name: "перевод встроенного типа", config: Config{Activities: []ActivityConfig{{Name: "Running", CaloriesCoefficient: 1}}}},
+		{name: "синоним-перевод", config: Config{Activities: []ActivityConfig{{Name: "Спринт", Aliases: []string{"Swimming"}, CaloriesCoefficient: 1}}}},
+		{name: "тип только с параметрами", config: Config{Activities: []ActivityConfig{{Name: "Велосипед", CaloriesCoefficient: 1}}}},
+		{name: "перевод совпадает с переводом встроенного", config: Config{Activities: []ActivityConfig{
+			{Name: "Прогулка", Translations: map[string]string{"en": "Walking"}, CaloriesCoefficient: 0.5},
+		}}},
+		{name: "перевод совпадает с названием встроенного", config: Config{Activities: []ActivityConfig{
+			{Name: "Прогулка", Translations: map[string]string{"en": "Бег"}, CaloriesCoefficient: 0.5},
+		}}},
+		{name: "перевод совпадает с названием из файла", config: Config{Activities: []ActivityConfig{
+			{Name: "Прогулка", CaloriesCoefficient: 0.5},
+			{Name: "Марш", Translations: map[string]string{"en": "Прогулка"}, CaloriesCoefficient: 0.5},
+		}}},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Error(suite.T(), RegisterConfig(tt.config))
+		})
+	}
+
+	_, ok := Lookup("Прогулка", 0)
+	assert.False(suite.T(), ok)
+	s, err := Calculate("6000,Running,1h00m", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Бег", s.Activity)
+
+	// Одинаковый перевод одного типа на разные языки допустим.
+	hiking := Config{Activities: []ActivityConfig{
+		{Name: "Хайкинг", Translations: map[string]string{"en": "Hiking", "de": "Hiking"}, CaloriesCoefficient: 0.6},
+	}}
+	require.NoError(suite.T(), RegisterConfig(hiking))
+	suite.T().Cleanup(func() { unregisterConfig(hiking) })
+}
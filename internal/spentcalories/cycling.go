@@ -0,0 +1,63 @@
+package spentcalories
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Велосипед передается пакетом "обороты педалей,Велосипед,длительность,..."
+// в одном из двух вариантов:
+//   - с дистанцией в километрах: "5400,Велосипед,1h00m,20";
+//   - с каденсом колеса (оборотов в минуту по датчику скорости) и окружностью
+//     колеса в метрах: "5400,Велосипед,1h00m,160,2.1".
+func init() {
+	MustRegister(Activity{
+		Names:     []string{"Велосипед"},
+		Params:    []string{"дистанция, км"},
+		Distance:  func(w Workout) float64 { return w.Params[0] },
+		Calories:  cyclingCalories,
+		Intensity: cyclingMET.intensity,
+	})
+	MustRegister(Activity{
+		Names:     []string{"Велосипед"},
+		Params:    []string{"каденс колеса, об/мин", "окружность колеса, м"},
+		Distance:  cadenceDistance,
+		Calories:  cyclingCalories,
+		Intensity: cyclingMET.intensity,
+	})
+}
+
+// cadenceDistance возвращает дистанцию в километрах по каденсу колеса
+// и его окружности.
+func cadenceDistance(w Workout) float64 {
+	cadence, circumference := w.Params[0], w.Params[1]
+	return cadence * w.Duration.Minutes() * circumference / units.MetersInKm
+}
+
+func cyclingCalories(w Workout) (float64, error) {
+	dist := w.Params[0]
+	if len(w.Params) == 2 {
+		dist = cadenceDistance(w)
+	}
+	return CyclingSpentCalories(dist, w.Profile.Weight, w.Duration)
+}
+
+// CyclingSpentCalories возвращает количество калорий, потраченных при езде
+// на велосипеде. Интенсивность определяется по средней скорости с помощью
+// таблицы MET, поэтому обе модели расчета дают для велосипеда одинаковый
+// результат.
+func CyclingSpentCalories(distance, weight float64, duration time.Duration) (float64, error) {
+	if distance <= 0 {
+		return 0, errors.New("дистанция должна быть больше нуля")
+	}
+	if weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	return METSpentCalories(cyclingMET.intensity(distance/duration.Hours()), weight, duration)
+}
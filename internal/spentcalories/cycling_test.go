@@ -0,0 +1,51 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *SpentCaloriesTestSuite) TestCycling() {
+	tests := []struct {
+		name     string
+		input    string
+		wantDist float64
+		wantCal  float64
+	}{
+		{
+			name:     "дистанция в километрах",
+			input:    "5400,Велосипед,1h00m,20",
+			wantDist: 20,
+			wantCal:  8.0 * 75,
+		},
+		{
+			name:     "каденс и окружность колеса",
+			input:    "5400,Велосипед,30m,160,2.1",
+			wantDist: 10.08,
+			wantCal:  8.0 * 75 * 0.5,
+		},
+		{
+			name:     "медленная езда",
+			input:    "1000,Велосипед,2h,10",
+			wantDist: 10,
+			wantCal:  4.0 * 75 * 2,
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := Calculate(tt.input, 75.0, 1.75)
+			require.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.wantDist, got.Distance, 1e-9)
+			assert.InDelta(suite.T(), tt.wantCal, got.Calories, 1e-9)
+		})
+	}
+
+	_, err := Calculate("5400,Велосипед,1h00m", 75.0, 1.75)
+	assert.ErrorIs(suite.T(), err, ErrUnknownTraining)
+
+	_, err = CyclingSpentCalories(20, 0, time.Hour)
+	assert.Error(suite.T(), err)
+}
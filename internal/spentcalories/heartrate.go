@@ -0,0 +1,52 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// keytelCoefficients — коэффициенты формулы Keytel et al. (2005) для расчета
+// расхода энергии по пульсу, кДж/мин:
+// intercept + hr*пульс + weight*вес + age*возраст.
+type keytelCoefficients struct {
+	intercept, hr, weight, age float64
+}
+
+var keytel = map[profile.Sex]keytelCoefficients{
+	profile.SexMale:   {intercept: -55.0969, hr: 0.6309, weight: 0.1988, age: 0.2017},
+	profile.SexFemale: {intercept: -20.4022, hr: 0.4472, weight: -0.1263, age: 0.074},
+}
+
+// kJInKcal — количество килоджоулей в килокалории.
+const kJInKcal = 4.184
+
+// HeartRateSpentCalories возвращает количество калорий по среднему пульсу
+// (формула Keytel). Нужны вес, возраст и пол из профиля.
+func HeartRateSpentCalories(hr int, p profile.Profile, duration time.Duration) (float64, error) {
+	c, ok := keytel[p.Sex]
+	if !ok {
+		return 0, errors.New("для расчета по пульсу нужно указать пол")
+	}
+	if p.Age <= 0 {
+		return 0, errors.New("для расчета по пульсу нужно указать возраст")
+	}
+	if p.Weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if hr <= 0 {
+		return 0, errors.New("пульс должен быть больше нуля")
+	}
+	if duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	perMinute := (c.intercept + c.hr*float64(hr) + c.weight*p.Weight + c.age*float64(p.Age)) / kJInKcal
+	if perMinute <= 0 {
+		return 0, fmt.Errorf("пульс %d уд/мин слишком низкий для расчета калорий", hr)
+	}
+
+	return perMinute * duration.Minutes(), nil
+}
@@ -0,0 +1,59 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+func (suite *SpentCaloriesTestSuite) TestHeartRateSpentCalories() {
+	got, err := HeartRateSpentCalories(150, profile.Profile{Weight: 75, Age: 30, Sex: profile.SexMale}, time.Hour)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 867.578, got, 1e-3)
+
+	got, err = HeartRateSpentCalories(140, profile.Profile{Weight: 60, Age: 25, Sex: profile.SexFemale}, 30*time.Minute)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 261.552, got, 1e-3)
+
+	_, err = HeartRateSpentCalories(150, profile.Profile{Weight: 75, Age: 30}, time.Hour)
+	assert.Error(suite.T(), err, "без пола")
+	_, err = HeartRateSpentCalories(150, profile.Profile{Weight: 75, Sex: profile.SexMale}, time.Hour)
+	assert.Error(suite.T(), err, "без возраста")
+	_, err = HeartRateSpentCalories(40, profile.Profile{Weight: 50, Age: 20, Sex: profile.SexMale}, time.Hour)
+	assert.Error(suite.T(), err, "слишком низкий пульс")
+}
+
+func (suite *SpentCaloriesTestSuite) TestCalculateHeartRate() {
+	p := profile.Profile{Weight: 75, Height: 1.75, Age: 30, Sex: profile.SexMale}
+
+	got, err := CalculateFor("6000,Бег,1h00m,hr=150", p)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 150, got.HeartRate)
+	assert.InDelta(suite.T(), 867.578, got.Calories, 1e-3)
+	assert.Equal(suite.T(), "Тип тренировки: Бег\nДлительность: 1.00 ч.\nДистанция: 4.72 км.\nСкорость: 4.72 км/ч\nСожгли калорий: 867.58\nСредний пульс: 150 уд/мин\n", got.String())
+
+	got, err = CalculateFor("1200,Плавание,1h00m,25,40,hr=150", p)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 867.578, got.Calories, 1e-3)
+
+	// Без возраста и пола пульс только выводится в отчете.
+	got, err = Calculate("6000,Бег,1h00m,hr=150", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 354.375, got.Calories, 1e-9)
+	assert.Equal(suite.T(), 150, got.HeartRate)
+
+	for _, input := range []string{
+		"6000,Бег,1h00m,hr=abc",
+		"6000,Бег,1h00m,hr=300",
+		"6000,Бег,1h00m,hr=150,hr=160",
+		"6000,Бег,1h00m,cadence=170",
+	} {
+		_, err := CalculateFor(input, p)
+		var pe *packet.ParseError
+		assert.ErrorAs(suite.T(), err, &pe, input)
+	}
+}
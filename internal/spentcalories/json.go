@@ -0,0 +1,137 @@
+package spentcalories
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// trainingSummaryJSON — JSON-представление TrainingSummary. Названия полей
+// и единицы измерения являются частью формата и не должны меняться.
+type trainingSummaryJSON struct {
+	Activity  string  `json:"activity"`
+	Steps     int     `json:"steps"`
+	Duration  float64 `json:"duration_s"`
+	Distance  float64 `json:"distance_km"`
+	Speed     float64 `json:"speed_kmh"`
+	Calories  float64 `json:"calories_kcal"`
+	HeartRate int     `json:"heart_rate_bpm,omitempty"`
+	Time      string  `json:"time,omitempty"`
+}
+
+// MarshalJSON кодирует отчет о тренировке в JSON.
+// Продолжительность передается в секундах.
+func (s TrainingSummary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.toJSON())
+}
+
+func (s TrainingSummary) toJSON() trainingSummaryJSON {
+	return trainingSummaryJSON{
+		Activity:  s.Activity,
+		Steps:     s.Steps,
+		Duration:  s.Duration.Seconds(),
+		Distance:  s.Distance,
+		Speed:     s.Speed,
+		Calories:  s.Calories,
+		HeartRate: s.HeartRate,
+		Time:      s.Stamp.String(),
+	}
+}
+
+// splitJSON — сплит в JSON-отчете; сплиты всегда считаются по километрам.
+type splitJSON struct {
+	Distance float64 `json:"distance_km"`
+	Duration float64 `json:"duration_s"`
+	Elapsed  float64 `json:"elapsed_s"`
+}
+
+// MarshalJSON кодирует отчет в JSON: поля TrainingSummary, темп
+// в секундах на километр и сплиты по километрам.
+func (r PaceReport) MarshalJSON() ([]byte, error) {
+	splits := make([]splitJSON, 0)
+	for _, split := range r.Summary.Splits(1) {
+		splits = append(splits, splitJSON{
+			Distance: split.Distance,
+			Duration: split.Duration.Seconds(),
+			Elapsed:  split.Elapsed.Seconds(),
+		})
+	}
+
+	return json.Marshal(struct {
+		trainingSummaryJSON
+		Pace   float64     `json:"pace_s_per_km"`
+		Splits []splitJSON `json:"splits"`
+	}{
+		trainingSummaryJSON: r.Summary.toJSON(),
+		Pace:                r.Summary.Pace().Seconds(),
+		Splits:              splits,
+	})
+}
+
+// zoneJSON — время в пульсовой зоне в JSON-отчете.
+type zoneJSON struct {
+	Zone     int     `json:"zone"`
+	Low      int     `json:"low_bpm"`
+	High     int     `json:"high_bpm"`
+	Duration float64 `json:"duration_s"`
+	Share    float64 `json:"share"`
+}
+
+// MarshalJSON кодирует отчет в JSON: поля базового отчета, максимальный
+// пульс, время в зонах и ниже первой зоны в секундах. Без показаний
+// пульса список зон пуст.
+func (r ZoneReport) MarshalJSON() ([]byte, error) {
+	base, err := json.Marshal(r.base())
+	if err != nil {
+		return nil, err
+	}
+
+	a := r.Analysis()
+	zones := make([]zoneJSON, 0, len(r.Zones))
+	if len(r.Summary.HeartRates) > 0 {
+		for i, zone := range r.Zones {
+			zones = append(zones, zoneJSON{
+				Zone:     zone.Number,
+				Low:      zone.Low,
+				High:     zone.High,
+				Duration: a.Time[i].Seconds(),
+				Share:    a.Share(zone.Number),
+			})
+		}
+	}
+
+	extra, err := json.Marshal(struct {
+		MaxHeartRate int        `json:"heart_rate_max_bpm,omitempty"`
+		Zones        []zoneJSON `json:"zones"`
+		Below        float64    `json:"below_zones_s"`
+	}{
+		MaxHeartRate: a.Max,
+		Zones:        zones,
+		Below:        a.Below.Seconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeObjects(base, extra)
+}
+
+// mergeObjects объединяет поля двух JSON-объектов, сохраняя их порядок:
+// сначала поля a, затем поля b.
+func mergeObjects(a, b []byte) ([]byte, error) {
+	a, b = bytes.TrimSpace(a), bytes.TrimSpace(b)
+	if len(a) < 2 || a[0] != '{' || a[len(a)-1] != '}' || len(b) < 2 || b[0] != '{' || b[len(b)-1] != '}' {
+		return nil, errors.New("ожидаются JSON-объекты")
+	}
+	if len(a) == 2 {
+		return b, nil
+	}
+	if len(b) == 2 {
+		return a, nil
+	}
+
+	merged := make([]byte, 0, len(a)+len(b))
+	merged = append(merged, a[:len(a)-1]...)
+	merged = append(merged, ',')
+	return append(merged, b[1:]...), nil
+}
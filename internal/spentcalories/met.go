@@ -0,0 +1,158 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// Model — модель расчета калорий.
+type Model string
+
+const (
+	// ModelLegacy — исходные формулы пакета (RunningSpentCalories,
+	// WalkingSpentCalories и т.д.). Используется по умолчанию, чтобы
+	// результаты оставались воспроизводимыми.
+	ModelLegacy Model = "legacy"
+	// ModelMET — расчет по значениям MET из Compendium of Physical Activities:
+	// калории = MET * вес * часы.
+	ModelMET Model = "met"
+)
+
+// ParseModel возвращает модель расчета калорий по её названию.
+// Пустая строка означает модель по умолчанию.
+func ParseModel(s string) (Model, error) {
+	switch Model(s) {
+	case "", ModelLegacy:
+		return ModelLegacy, nil
+	case ModelMET:
+		return ModelMET, nil
+	default:
+		return "", fmt.Errorf("неизвестная модель расчета калорий %q", s)
+	}
+}
+
+// IntensityFunc возвращает MET тренировки по её средней скорости в км/ч.
+type IntensityFunc func(speed float64) float64
+
+// metLevel — строка таблицы MET: значение действует, пока скорость
+// ниже maxSpeed (км/ч).
+type metLevel struct {
+	maxSpeed float64
+	met      float64
+}
+
+// metTable — таблица MET по скорости. Для скорости выше последней строки
+// используется значение top.
+type metTable struct {
+	levels []metLevel
+	top    float64
+}
+
+func (t metTable) intensity(speed float64) float64 {
+	for _, level := range t.levels {
+		if speed < level.maxSpeed {
+			return level.met
+		}
+	}
+	return t.top
+}
+
+// Таблицы MET по Compendium of Physical Activities.
+var (
+	// Ходьба, коды 17151–17231.
+	walkingMET = metTable{
+		levels: []metLevel{
+			{maxSpeed: 3.2, met: 2.0},
+			{maxSpeed: 4.0, met: 2.8},
+			{maxSpeed: 4.8, met: 3.0},
+			{maxSpeed: 5.6, met: 3.5},
+			{maxSpeed: 6.4, met: 4.3},
+			{maxSpeed: 7.2, met: 5.0},
+			{maxSpeed: 8.0, met: 7.0},
+		},
+		top: 8.3,
+	}
+	// Бег, коды 12020–12150.
+	runningMET = metTable{
+		levels: []metLevel{
+			{maxSpeed: 8.0, met: 6.0},
+			{maxSpeed: 9.7, met: 8.3},
+			{maxSpeed: 10.8, met: 9.8},
+			{maxSpeed: 11.3, met: 11.0},
+			{maxSpeed: 12.1, met: 11.8},
+			{maxSpeed: 12.9, met: 12.3},
+			{maxSpeed: 13.8, met: 12.8},
+			{maxSpeed: 14.5, met: 14.5},
+			{maxSpeed: 16.1, met: 16.0},
+			{maxSpeed: 17.7, met: 19.0},
+			{maxSpeed: 19.3, met: 19.8},
+		},
+		top: 23.0,
+	}
+	// Плавание в бассейне, коды 18310–18360.
+	swimmingMET = metTable{
+		levels: []metLevel{
+			{maxSpeed: 2.0, met: 5.8},
+			{maxSpeed: 3.0, met: 8.3},
+		},
+		top: 9.8,
+	}
+	// Велосипед, коды 01010–01050.
+	cyclingMET = metTable{
+		levels: []metLevel{
+			{maxSpeed: 16, met: 4.0},
+			{maxSpeed: 19, met: 6.8},
+			{maxSpeed: 22.5, met: 8.0},
+			{maxSpeed: 25.5, met: 10.0},
+			{maxSpeed: 30.5, met: 12.0},
+		},
+		top: 15.8,
+	}
+)
+
+// METSpentCalories возвращает количество калорий по значению MET:
+// калории = MET * вес * часы.
+func METSpentCalories(met, weight float64, duration time.Duration) (float64, error) {
+	if met <= 0 {
+		return 0, errors.New("значение MET должно быть больше нуля")
+	}
+	if weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	return met * weight * duration.Hours(), nil
+}
+
+// spentCalories считает калории тренировки в указанной модели.
+// dist — уже рассчитанная дистанция тренировки в километрах. Если в пакете
+// есть пульс, а в профиле — возраст и пол, формула по пульсу
+// (HeartRateSpentCalories) имеет приоритет над моделью.
+func (a Activity) spentCalories(w Workout, dist float64, model Model) (float64, error) {
+	if w.HeartRate > 0 && w.Profile.Age > 0 && w.Profile.Sex != profile.SexUnknown {
+		return HeartRateSpentCalories(w.HeartRate, w.Profile, w.Duration)
+	}
+
+	switch model {
+	case "", ModelLegacy:
+		return a.Calories(w)
+	case ModelMET:
+		if a.Intensity == nil {
+			return 0, fmt.Errorf("тип тренировки %s не поддерживает модель %s", a.Names[0], model)
+		}
+		if w.Duration <= 0 {
+			return 0, errors.New("продолжительность должна быть больше нуля")
+		}
+		if dist <= 0 {
+			return 0, errors.New("дистанция должна быть больше нуля")
+		}
+		return METSpentCalories(a.Intensity(dist/w.Duration.Hours()), w.Profile.Weight, w.Duration)
+	default:
+		return 0, fmt.Errorf("неизвестная модель расчета калорий %q", model)
+	}
+}
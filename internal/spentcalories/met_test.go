@@ -0,0 +1,53 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *SpentCaloriesTestSuite) TestCalculateWithModel() {
+	tests := []struct {
+		name    string
+		input   string
+		model   Model
+		wantCal float64
+	}{
+		{name: "бег, legacy", input: "6000,Бег,1h00m", model: ModelLegacy, wantCal: 354.375},
+		{name: "бег, MET", input: "6000,Бег,1h00m", model: ModelMET, wantCal: 6.0 * 75},
+		{name: "быстрый бег, MET", input: "20000,Бег,1h00m", model: ModelMET, wantCal: 16.0 * 75},
+		{name: "ходьба, MET", input: "6000,Ходьба,1h00m", model: ModelMET, wantCal: 3.0 * 75},
+		{name: "плавание, MET", input: "1200,Плавание,1h00m,25,40", model: ModelMET, wantCal: 5.8 * 75},
+		{name: "велосипед, MET", input: "5400,Велосипед,1h00m,20", model: ModelMET, wantCal: 8.0 * 75},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := CalculateWithModel(tt.input, 75.0, 1.75, tt.model)
+			require.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.wantCal, got.Calories, 1e-9)
+		})
+	}
+
+	_, err := CalculateWithModel("6000,Бег,1h00m", 75.0, 0, ModelMET)
+	assert.Error(suite.T(), err)
+	_, err = CalculateWithModel("6000,Бег,1h00m", 75.0, 1.75, Model("unknown"))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestParseModel() {
+	m, err := ParseModel("")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ModelLegacy, m)
+
+	m, err = ParseModel("met")
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), ModelMET, m)
+
+	_, err = ParseModel("MET2")
+	assert.Error(suite.T(), err)
+
+	_, err = METSpentCalories(0, 75, time.Hour)
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,115 @@
+package spentcalories
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+)
+
+// Pace возвращает средний темп тренировки — время на километр,
+// рассчитанное по средней скорости. Без дистанции возвращается 0.
+func (s TrainingSummary) Pace() time.Duration {
+	if s.Speed <= 0 {
+		return 0
+	}
+	return time.Duration(float64(time.Hour) / s.Speed)
+}
+
+// HasPace сообщает, считаются ли для типа тренировки темп и сплиты
+// (см. Activity.Pace).
+func (s TrainingSummary) HasPace() bool {
+	return HasPace(s.Activity)
+}
+
+// HasPace сообщает, считаются ли темп и сплиты для типа тренировки
+// с основным названием name. Для незарегистрированных типов возвращается
+// false.
+func HasPace(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for key, a := range registry {
+		if key.name == name && a.Pace {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxSplits — наибольшее количество сплитов в отчете. Для более длинных
+// дистанций сплиты не считаются: такая дистанция получается только
+// из ошибочного пакета, а список занял бы всю память.
+const MaxSplits = 1000
+
+// Split — отрезок тренировки.
+type Split struct {
+	Distance float64       // дистанция от старта до конца отрезка в километрах.
+	Duration time.Duration // время отрезка.
+	Elapsed  time.Duration // время от старта до конца отрезка.
+}
+
+// Splits оценивает отрезки тренировки длиной length километров (1 —
+// по километрам, units.KmInMile — по милям), считая темп равномерным.
+// Последний отрезок может быть короче. Если отрезков больше MaxSplits,
+// возвращается nil.
+func (s TrainingSummary) Splits(length float64) []Split {
+	pace := s.Pace()
+	if pace <= 0 || length <= 0 {
+		return nil
+	}
+
+	count := math.Ceil(s.Distance/length - 1e-9)
+	if count > MaxSplits {
+		return nil
+	}
+
+	n := int(count)
+	splits := make([]Split, 0, n)
+	for i := 1; i <= n; i++ {
+		dist := min(float64(i)*length, s.Distance)
+		prev := float64(i-1) * length
+		splits = append(splits, Split{
+			Distance: dist,
+			Duration: time.Duration((dist - prev) * float64(pace)),
+			Elapsed:  time.Duration(dist * float64(pace)),
+		})
+	}
+
+	return splits
+}
+
+// PaceReport — отчет о тренировке с темпом и сплитами.
+type PaceReport struct {
+	Summary TrainingSummary
+}
+
+// String возвращает отчет в текстовом виде на языке по умолчанию
+// и в метрических единицах.
+func (r PaceReport) String() string {
+	return r.Format(i18n.Locale{})
+}
+
+// Format возвращает отчет о тренировке, дополненный темпом и сплитами
+// по километрам или милям в зависимости от системы единиц локали.
+func (r PaceReport) Format(loc i18n.Locale) string {
+	var b strings.Builder
+	b.WriteString(r.Summary.Format(loc))
+
+	pace, paceUnit := loc.Pace(r.Summary.Pace())
+	b.WriteString(loc.Sprintf(i18n.MsgPace, pace, paceUnit))
+
+	splits := r.Summary.Splits(loc.Units.Kilometers(1))
+	if len(splits) == 0 {
+		return b.String()
+	}
+
+	b.WriteString(loc.Sprintf(i18n.MsgSplitsHeader))
+	for _, split := range splits {
+		dist, unit := loc.Distance(split.Distance)
+		b.WriteString(loc.Sprintf(i18n.MsgSplit, dist, unit, i18n.Clock(split.Duration), i18n.Clock(split.Elapsed)))
+	}
+
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package spentcalories
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *SpentCaloriesTestSuite) TestPace() {
+	got, err := Calculate("6000,Бег,30m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+	require.True(suite.T(), got.HasPace())
+
+	// 4.725 км за 30 минут — 9.45 км/ч, то есть 6:21 на километр.
+	assert.InDelta(suite.T(), (6*time.Minute + 21*time.Second).Seconds(), got.Pace().Seconds(), 0.1)
+	assert.InDelta(suite.T(), time.Hour.Seconds()/meanSpeed(6000, 1.75, 30*time.Minute), got.Pace().Seconds(), 1e-6)
+
+	walk, err := Calculate("6000,Ходьба,30m", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+	assert.False(suite.T(), walk.HasPace())
+
+	assert.Zero(suite.T(), TrainingSummary{}.Pace())
+}
+
+func (suite *SpentCaloriesTestSuite) TestSplits() {
+	s := TrainingSummary{Activity: "Бег", Duration: 30 * time.Minute, Distance: 4.725, Speed: 9.45}
+
+	splits := s.Splits(1)
+	require.Len(suite.T(), splits, 5)
+	for _, split := range splits[:4] {
+		assert.InDelta(suite.T(), s.Pace().Seconds(), split.Duration.Seconds(), 1e-6)
+	}
+	assert.InDelta(suite.T(), 4.725, splits[4].Distance, 1e-9)
+	assert.InDelta(suite.T(), 0.725*s.Pace().Seconds(), splits[4].Duration.Seconds(), 1e-6)
+	assert.InDelta(suite.T(), s.Duration.Seconds(), splits[4].Elapsed.Seconds(), 1e-6)
+
+	miles := s.Splits(units.KmInMile)
+	require.Len(suite.T(), miles, 3)
+	assert.InDelta(suite.T(), 2*units.KmInMile, miles[1].Distance, 1e-9)
+
+	assert.Len(suite.T(), TrainingSummary{Distance: 3, Speed: 6}.Splits(1), 3)
+	assert.Nil(suite.T(), TrainingSummary{}.Splits(1))
+}
+
+func (suite *SpentCaloriesTestSuite) TestPaceReport() {
+	s := TrainingSummary{Activity: "Бег", Steps: 6000, Duration: 15 * time.Minute, Distance: 2.5, Speed: 10, Calories: 200}
+	r := PaceReport{Summary: s}
+
+	assert.Equal(suite.T(), s.String()+
+		"Темп: 6:00 мин/км\n"+
+		"Сплиты:\n"+
+		"  1.00 км — 6:00 (всего 6:00)\n"+
+		"  2.00 км — 6:00 (всего 12:00)\n"+
+		"  2.50 км — 3:00 (всего 15:00)\n", r.String())
+
+	text := r.Format(i18n.Locale{Lang: i18n.English, Units: units.Imperial})
+	assert.Contains(suite.T(), text, "Pace: 9:39 min/mi\n")
+	assert.Contains(suite.T(), text, "  1.00 mi — 9:39 (total 9:39)\n")
+	assert.Contains(suite.T(), text, "  1.55 mi — 5:21 (total 15:00)\n")
+
+	data, err := json.Marshal(r)
+	require.NoError(suite.T(), err)
+	assert.JSONEq(suite.T(), `{
+		"activity": "Бег", "steps": 6000, "duration_s": 900, "distance_km": 2.5,
+		"speed_kmh": 10, "calories_kcal": 200, "pace_s_per_km": 360,
+		"splits": [
+			{"distance_km": 1, "duration_s": 360, "elapsed_s": 360},
+			{"distance_km": 2, "duration_s": 360, "elapsed_s": 720},
+			{"distance_km": 2.5, "duration_s": 180, "elapsed_s": 900}
+		]
+	}`, string(data))
+}
+
+func (suite *SpentCaloriesTestSuite) TestSplitsLimit() {
+	s, err := Calculate("1000000000000,Бег,1h", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Nil(suite.T(), s.Splits(1))
+
+	r := PaceReport{Summary: s}
+	assert.NotContains(suite.T(), r.String(), "Сплиты")
+	data, err := json.Marshal(r)
+	require.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(data), `"splits":[]`)
+
+	atLimit := TrainingSummary{Distance: MaxSplits, Speed: 10}
+	assert.Len(suite.T(), atLimit.Splits(1), MaxSplits)
+	assert.Nil(suite.T(), TrainingSummary{Distance: MaxSplits + 0.5, Speed: 10}.Splits(1))
+}
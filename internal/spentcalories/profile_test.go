@@ -0,0 +1,24 @@
+package spentcalories
+
+import (
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (suite *SpentCaloriesTestSuite) TestCalculateFor() {
+	p := profile.Profile{Weight: 75.0, Height: 1.75, StepLength: 0.8}
+
+	got, err := CalculateFor("6000,Бег,1h00m", p)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 4.8, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 75*4.8, got.Calories, 1e-9)
+
+	p.Model = string(ModelMET)
+	got, err = CalculateFor("6000,Бег,1h00m", p)
+	require.NoError(suite.T(), err)
+	assert.InDelta(suite.T(), 6.0*75, got.Calories, 1e-9)
+
+	_, err = CalculateFor("6000,Бег,1h00m", profile.Profile{Weight: 75.0, Height: 1.75, Sex: "?"})
+	assert.Error(suite.T(), err)
+}
@@ -0,0 +1,220 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+// ErrUnknownTraining возвращается, если тип тренировки не зарегистрирован.
+// Совпадает с packet.ErrUnknownActivity.
+var ErrUnknownTraining = packet.ErrUnknownActivity
+
+// Workout — данные одной тренировки, передаваемые в расчеты типа тренировки.
+type Workout struct {
+	Steps    int             // количество шагов (для плавания — гребков).
+	Duration time.Duration   // продолжительность тренировки.
+	Params   []float64       // дополнительные параметры пакета в порядке Activity.Params.
+	Profile  profile.Profile // параметры пользователя.
+	// HeartRate — средний пульс, уд/мин; 0 — пульс в пакете не указан.
+	HeartRate int
+	// HeartRates — показания пульса, равномерно распределенные
+	// по тренировке; из них рассчитывается HeartRate.
+	HeartRates []int
+}
+
+// DistanceFunc рассчитывает дистанцию тренировки в километрах.
+type DistanceFunc func(w Workout) float64
+
+// CaloriesFunc рассчитывает количество калорий, потраченных на тренировке.
+type CaloriesFunc func(w Workout) (float64, error)
+
+// Activity описывает тип тренировки, который умеет обрабатывать TrainingInfo.
+type Activity struct {
+	// Names — названия, под которыми тип тренировки встречается в пакетах данных.
+	Names []string
+	// Params — названия дополнительных параметров, которые следуют в пакете
+	// за продолжительностью. Один и тот же тип может быть зарегистрирован
+	// несколько раз с разным набором параметров.
+	Params []string
+	// Validate — проверка дополнительных параметров, которую нельзя
+	// выразить их количеством, например целое число кругов. Ошибки
+	// возвращаются в виде *packet.ParseError; исходный пакет в них
+	// подставляет CalculateFor. Может быть не задана.
+	Validate func(w Workout) error
+	// Distance — модель расчета дистанции.
+	Distance DistanceFunc
+	// Calories — формула расчета калорий в модели ModelLegacy.
+	Calories CaloriesFunc
+	// Intensity — значение MET для модели ModelMET. Если не задано,
+	// тип тренировки поддерживает только ModelLegacy.
+	Intensity IntensityFunc
+	// Pace — для тренировок этого типа считаются темп и сплиты
+	// (см. PaceReport).
+	Pace bool
+	// Steps — первое поле пакета — шаги. У плавания и велосипеда там
+	// гребки и обороты педалей, они не учитываются в шагах за день
+	// (см. CountsSteps).
+	Steps bool
+}
+
+// registryKey различает варианты типа тренировки по количеству параметров.
+type registryKey struct {
+	name   string
+	params int
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[registryKey]Activity)
+)
+
+func init() {
+	MustRegister(Activity{
+		Names:     []string{"Бег"},
+		Distance:  stepDistance,
+		Calories:  runningCalories,
+		Intensity: runningMET.intensity,
+		Pace:      true,
+		Steps:     true,
+	})
+	MustRegister(Activity{
+		Names:     []string{"Ходьба"},
+		Distance:  stepDistance,
+		Calories:  walkingCalories,
+		Intensity: walkingMET.intensity,
+		Steps:     true,
+	})
+}
+
+// Register добавляет тип тренировки в реестр. Названия не должны совпадать
+// с уже зарегистрированными вариантами с тем же количеством параметров.
+func Register(a Activity) error {
+	if len(a.Names) == 0 {
+		return errors.New("у типа тренировки должно быть хотя бы одно название")
+	}
+	if a.Distance == nil || a.Calories == nil {
+		return errors.New("для типа тренировки нужно указать расчет дистанции и калорий")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for _, name := range a.Names {
+		if name == "" {
+			return errors.New("название типа тренировки не может быть пустым")
+		}
+		if _, ok := registry[registryKey{name, len(a.Params)}]; ok {
+			return fmt.Errorf("тип тренировки %q уже зарегистрирован", name)
+		}
+	}
+	for _, name := range a.Names {
+		registry[registryKey{name, len(a.Params)}] = a
+	}
+
+	return nil
+}
+
+// MustRegister работает как Register, но паникует при ошибке.
+// Предназначена для регистрации типов тренировок в init.
+func MustRegister(a Activity) {
+	if err := Register(a); err != nil {
+		panic(err)
+	}
+}
+
+// Lookup возвращает вариант типа тренировки по названию и количеству
+// дополнительных параметров.
+func Lookup(name string, params int) (Activity, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	a, ok := registry[registryKey{name, params}]
+	return a, ok
+}
+
+// lookupWorkout ищет тип тренировки для пакета. Название может быть
+// переводом из каталогов i18n. Если название известно, но с другим набором
+// параметров, в ошибке перечисляются ожидаемые параметры.
+func lookupWorkout(name string, params int) (Activity, error) {
+	if a, ok := Lookup(name, params); ok {
+		return a, nil
+	}
+	if canonical, ok := i18n.CanonicalActivity(name); ok {
+		name = canonical
+		if a, ok := Lookup(name, params); ok {
+			return a, nil
+		}
+	}
+
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	var variants []string
+	for key, a := range registry {
+		if key.name == name {
+			variants = append(variants, "["+strings.Join(a.Params, ", ")+"]")
+		}
+	}
+	if len(variants) == 0 {
+		return Activity{}, ErrUnknownTraining
+	}
+
+	sort.Strings(variants)
+	return Activity{}, fmt.Errorf("%w с %d доп. параметрами, ожидаются параметры %s",
+		ErrUnknownTraining, params, strings.Join(variants, " или "))
+}
+
+// isRegistered сообщает, зарегистрирован ли тип тренировки name с любым
+// набором параметров.
+func isRegistered(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for key := range registry {
+		if key.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CountsSteps сообщает, что первое поле пакетов типа тренировки name — шаги
+// (см. Activity.Steps). Для незарегистрированных типов возвращается false.
+func CountsSteps(name string) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	for key, a := range registry {
+		if key.name == name && a.Steps {
+			return true
+		}
+	}
+	return false
+}
+
+// Activities возвращает отсортированный список названий всех
+// зарегистрированных типов тренировок.
+func Activities() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	seen := make(map[string]bool)
+	names := make([]string, 0, len(registry))
+	for key := range registry {
+		if !seen[key.name] {
+			seen[key.name] = true
+			names = append(names, key.name)
+		}
+	}
+	sort.Strings(names)
+
+	return names
+}
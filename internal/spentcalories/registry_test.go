@@ -0,0 +1,79 @@
+package spentcalories
+
+import (
+	"slices"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unregister удаляет из реестра все варианты типов тренировок с указанными
+// названиями, чтобы тесты не оставляли после себя зарегистрированных типов.
+func unregister(names ...string) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	for key := range registry {
+		if slices.Contains(names, key.name) {
+			delete(registry, key)
+		}
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestRegister() {
+	suite.T().Cleanup(func() { unregister("Гребля", "Rowing") })
+
+	err := Register(Activity{
+		Names:    []string{"Гребля", "Rowing"},
+		Distance: stepDistance,
+		Calories: func(w Workout) (float64, error) {
+			return 100, nil
+		},
+	})
+	require.NoError(suite.T(), err)
+
+	a, ok := Lookup("Rowing", 0)
+	assert.True(suite.T(), ok)
+	assert.Equal(suite.T(), []string{"Гребля", "Rowing"}, a.Names)
+	assert.Contains(suite.T(), Activities(), "Гребля")
+
+	got, err := TrainingInfo("6000,Гребля,1h00m", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Тип тренировки: Гребля\nДлительность: 1.00 ч.\nДистанция: 4.72 км.\nСкорость: 4.72 км/ч\nСожгли калорий: 100.00\n", got)
+
+	_, err = TrainingInfo("6000,Гребля,1h00m,10", 75, 1.75)
+	assert.ErrorIs(suite.T(), err, ErrUnknownTraining)
+}
+
+func (suite *SpentCaloriesTestSuite) TestRegisterErrors() {
+	tests := []struct {
+		name     string
+		activity Activity
+	}{
+		{
+			name:     "без названий",
+			activity: Activity{Distance: stepDistance, Calories: func(Workout) (float64, error) { return 0, nil }},
+		},
+		{
+			name:     "пустое название",
+			activity: Activity{Names: []string{""}, Distance: stepDistance, Calories: func(Workout) (float64, error) { return 0, nil }},
+		},
+		{
+			name:     "без расчета калорий",
+			activity: Activity{Names: []string{"Йога"}, Distance: stepDistance},
+		},
+		{
+			name:     "повторная регистрация",
+			activity: Activity{Names: []string{"Бег"}, Distance: stepDistance, Calories: func(Workout) (float64, error) { return 0, nil }},
+		},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			assert.Error(suite.T(), Register(tt.activity))
+		})
+	}
+
+	_, ok := Lookup("Йога", 0)
+	assert.False(suite.T(), ok)
+}
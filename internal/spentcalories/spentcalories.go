@@ -1,38 +1,304 @@
 package spentcalories
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/heartrate"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+	"github.com/Yandex-Practicum/tracker/internal/stride"
 )
 
 // Основные константы, необходимые для расчетов.
 const (
-	lenStep                    = 0.65 // средняя длина шага.
-	mInKm                      = 1000 // количество метров в километре.
-	minInH                     = 60   // количество минут в часе.
-	stepLengthCoefficient      = 0.45 // коэффициент для расчета длины шага на основе роста.
-	walkingCaloriesCoefficient = 0.5  // коэффициент для расчета калорий при ходьбе
+	minInH                     = 60  // количество минут в часе.
+	walkingCaloriesCoefficient = 0.5 // коэффициент для расчета калорий при ходьбе
 )
 
+// parseTraining разбирает пакет данных вида "3456,Ходьба,3h00m" и возвращает
+// количество шагов, тип тренировки и её продолжительность. Ошибки
+// возвращаются в виде *packet.ParseError.
 func parseTraining(data string) (int, string, time.Duration, error) {
-	// TODO: реализовать функцию
+	parts, err := packet.Split(data, 3, 3)
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	return parseFields(data, parts)
 }
 
+// parseFields разбирает обязательные поля пакета тренировки.
+func parseFields(data string, parts []string) (int, string, time.Duration, error) {
+	steps, err := packet.Steps(data, parts[0])
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	duration, err := packet.Duration(data, parts[2])
+	if err != nil {
+		return 0, "", 0, err
+	}
+
+	return steps, parts[1], duration, nil
+}
+
+// distance возвращает дистанцию в километрах, пройденную за указанное
+// количество шагов. Длина шага рассчитывается на основе роста.
 func distance(steps int, height float64) float64 {
-	// TODO: реализовать функцию
+	stepLength, err := stride.HeightBased.StepLength(height, 0)
+	if err != nil {
+		return 0
+	}
+	return stride.Distance(steps, stepLength)
 }
 
+// meanSpeed возвращает среднюю скорость в км/ч.
 func meanSpeed(steps int, height float64, duration time.Duration) float64 {
-	// TODO: реализовать функцию
+	if duration <= 0 {
+		return 0
+	}
+	return distance(steps, height) / duration.Hours()
+}
+
+// stepDistance возвращает дистанцию тренировки по шагам в километрах.
+// По умолчанию длина шага рассчитывается по росту (stride.HeightBased),
+// модель из профиля имеет приоритет.
+func stepDistance(w Workout) float64 {
+	length, err := w.Profile.StrideLength(stride.HeightBased)
+	if err != nil {
+		return 0
+	}
+	return stride.Distance(w.Steps, length)
+}
+
+// stepSpeed возвращает среднюю скорость тренировки по шагам в км/ч.
+func stepSpeed(w Workout) float64 {
+	if w.Duration <= 0 {
+		return 0
+	}
+	return stepDistance(w) / w.Duration.Hours()
+}
+
+// TrainingSummary — результат расчета одной тренировки.
+type TrainingSummary struct {
+	Activity string        // основное название типа тренировки.
+	Steps    int           // количество шагов.
+	Duration time.Duration // продолжительность тренировки.
+	Distance float64       // дистанция в километрах.
+	Speed    float64       // средняя скорость в км/ч.
+	Calories float64       // потраченные калории, ккал.
+	// HeartRate — средний пульс, уд/мин; 0 — пульс в пакете не указан.
+	HeartRate int
+	// HeartRates — показания пульса из пакета (см. packet.OptionHeartRate).
+	HeartRates []int
+	Stamp      packet.Stamp // метка времени пакета, пустая для пакетов версии 1.
+}
+
+// String возвращает отчет о тренировке в текстовом виде на языке
+// по умолчанию и в метрических единицах.
+func (s TrainingSummary) String() string {
+	return s.Format(i18n.Locale{})
+}
+
+// Format возвращает отчет о тренировке на языке и в единицах локали.
+func (s TrainingSummary) Format(loc i18n.Locale) string {
+	dist, distUnit := loc.Distance(s.Distance)
+	speed, speedUnit := loc.Speed(s.Speed)
+	report := loc.Sprintf(i18n.MsgTrainingReport,
+		loc.Activity(s.Activity), s.Duration.Hours(), dist, distUnit, speed, speedUnit, s.Calories)
+	if s.HeartRate == 0 {
+		return report
+	}
+
+	var b strings.Builder
+	b.WriteString(report)
+	b.WriteString(loc.Sprintf(i18n.MsgHeartRate, s.HeartRate))
+	return b.String()
+}
+
+// parseWorkout разбирает пакет тренировки вместе с дополнительными
+// параметрами, которые следуют за обязательными полями
+// (например, "1200,Плавание,1h00m,25,40"). Ошибки разбора тела пакета
+// указывают на весь пакет data вместе с заголовком.
+func parseWorkout(data, body string) (string, Workout, error) {
+	parts, err := packet.Split(body, 3, -1)
+	if err != nil {
+		return "", Workout{}, packet.WithInput(err, data)
+	}
+
+	steps, name, duration, err := parseFields(data, parts)
+	if err != nil {
+		return "", Workout{}, err
+	}
+
+	w := Workout{Steps: steps, Duration: duration, Params: make([]float64, 0, len(parts)-3)}
+	for _, field := range parts[3:] {
+		if key, value, ok := packet.Option(field); ok {
+			if err := w.setOption(data, key, value); err != nil {
+				return "", Workout{}, err
+			}
+			continue
+		}
+
+		v, err := packet.Param(data, field)
+		if err != nil {
+			return "", Workout{}, err
+		}
+		w.Params = append(w.Params, v)
+	}
+
+	return name, w, nil
+}
+
+// setOption применяет именованное поле пакета (см. packet.Option).
+func (w *Workout) setOption(data, key, value string) error {
+	switch key {
+	case packet.OptionHeartRate:
+		if w.HeartRate != 0 {
+			return &packet.ParseError{Input: data, Field: packet.FieldHeartRate, Value: value, Err: fmt.Errorf("%w: пульс указан дважды", packet.ErrBadHeartRate)}
+		}
+		series, err := packet.HeartRates(data, value)
+		if err != nil {
+			return err
+		}
+		w.HeartRates = series
+		w.HeartRate = heartrate.Average(series)
+		return nil
+	default:
+		return &packet.ParseError{Input: data, Field: packet.FieldParam, Value: key + "=" + value, Err: fmt.Errorf("%w: неизвестное поле %q", packet.ErrBadParam, key)}
+	}
 }
 
+// Calculate разбирает пакет данных и рассчитывает по нему тренировку
+// в модели ModelLegacy. Тип тренировки ищется в реестре (см. Register).
+func Calculate(data string, weight, height float64) (TrainingSummary, error) {
+	return CalculateFor(data, profile.Profile{Weight: weight, Height: height})
+}
+
+// CalculateWithModel работает как Calculate, но считает калории
+// в указанной модели.
+func CalculateWithModel(data string, weight, height float64, model Model) (TrainingSummary, error) {
+	return CalculateFor(data, profile.Profile{Weight: weight, Height: height, Model: string(model)})
+}
+
+// CalculateFor рассчитывает тренировку для профиля пользователя.
+// Модель расчета калорий берется из профиля. Пакет может содержать
+// заголовок с меткой времени (см. packet.Unstamp).
+func CalculateFor(data string, p profile.Profile) (TrainingSummary, error) {
+	if err := p.Validate(); err != nil {
+		return TrainingSummary{}, err
+	}
+
+	model, err := ParseModel(p.Model)
+	if err != nil {
+		return TrainingSummary{}, err
+	}
+
+	_, stamp, body, err := packet.Unstamp(data)
+	if err != nil {
+		return TrainingSummary{}, err
+	}
+
+	name, w, err := parseWorkout(data, body)
+	if err != nil {
+		return TrainingSummary{}, err
+	}
+	w.Profile = p
+
+	activity, err := lookupWorkout(name, len(w.Params))
+	if err != nil {
+		return TrainingSummary{}, &packet.ParseError{Input: data, Field: packet.FieldActivity, Value: name, Err: err}
+	}
+	if activity.Validate != nil {
+		if err := activity.Validate(w); err != nil {
+			return TrainingSummary{}, packet.WithInput(err, data)
+		}
+	}
+
+	dist := activity.Distance(w)
+
+	calories, err := activity.spentCalories(w, dist, model)
+	if err != nil {
+		return TrainingSummary{}, err
+	}
+
+	return TrainingSummary{
+		Activity:   activity.Names[0],
+		Steps:      w.Steps,
+		Duration:   w.Duration,
+		Distance:   dist,
+		Speed:      dist / w.Duration.Hours(),
+		Calories:   calories,
+		HeartRate:  w.HeartRate,
+		HeartRates: w.HeartRates,
+		Stamp:      stamp,
+	}, nil
+}
+
+// TrainingInfo возвращает информацию о тренировке: тип, длительность,
+// дистанцию, среднюю скорость и потраченные калории.
 func TrainingInfo(data string, weight, height float64) (string, error) {
-	// TODO: реализовать функцию
+	summary, err := Calculate(data, weight, height)
+	if err != nil {
+		return "", err
+	}
+
+	return summary.String(), nil
+}
+
+// validate проверяет общие для всех расчетов калорий по шагам параметры.
+func validate(w Workout) error {
+	if w.Steps <= 0 {
+		return errors.New("количество шагов должно быть больше нуля")
+	}
+	if w.Profile.Weight <= 0 {
+		return errors.New("вес должен быть больше нуля")
+	}
+	if _, err := w.Profile.StrideLength(stride.HeightBased); err != nil {
+		return err
+	}
+	if w.Duration <= 0 {
+		return errors.New("продолжительность должна быть больше нуля")
+	}
+	return nil
 }
 
+// RunningSpentCalories возвращает количество калорий, потраченных при беге.
 func RunningSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
-	// TODO: реализовать функцию
+	return runningCalories(Workout{
+		Steps:    steps,
+		Duration: duration,
+		Profile:  profile.Profile{Weight: weight, Height: height},
+	})
+}
+
+func runningCalories(w Workout) (float64, error) {
+	if err := validate(w); err != nil {
+		return 0, err
+	}
+
+	return w.Profile.Weight * stepSpeed(w) * w.Duration.Minutes() / minInH, nil
 }
 
+// WalkingSpentCalories возвращает количество калорий, потраченных при ходьбе.
 func WalkingSpentCalories(steps int, weight, height float64, duration time.Duration) (float64, error) {
-	// TODO: реализовать функцию
+	return WalkingSpentCaloriesFor(steps, duration, profile.Profile{Weight: weight, Height: height})
+}
+
+// WalkingSpentCaloriesFor работает как WalkingSpentCalories, но учитывает
+// модель длины шага из профиля.
+func WalkingSpentCaloriesFor(steps int, duration time.Duration, p profile.Profile) (float64, error) {
+	return walkingCalories(Workout{Steps: steps, Duration: duration, Profile: p})
+}
+
+func walkingCalories(w Workout) (float64, error) {
+	if err := validate(w); err != nil {
+		return 0, err
+	}
+
+	return w.Profile.Weight * stepSpeed(w) * w.Duration.Minutes() / minInH * walkingCaloriesCoefficient, nil
 }
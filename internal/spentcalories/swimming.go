@@ -0,0 +1,67 @@
+package spentcalories
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Плавание передается пакетом "гребки,Плавание,длительность,длина бассейна,круги",
+// например "1200,Плавание,1h00m,25,40". Длина бассейна указывается в метрах.
+func init() {
+	MustRegister(Activity{
+		Names:     []string{"Плавание"},
+		Params:    []string{"длина бассейна, м", "количество кругов"},
+		Validate:  validateSwimming,
+		Distance:  swimmingDistance,
+		Intensity: swimmingMET.intensity,
+		Calories: func(w Workout) (float64, error) {
+			return SwimmingSpentCalories(w.Params[0], int(w.Params[1]), w.Profile.Weight, w.Duration)
+		},
+	})
+}
+
+// validateSwimming проверяет, что количество кругов — целое число.
+func validateSwimming(w Workout) error {
+	if laps := w.Params[1]; math.Trunc(laps) != laps {
+		return &packet.ParseError{
+			Field: packet.FieldParam,
+			Value: strconv.FormatFloat(laps, 'f', -1, 64),
+			Err:   fmt.Errorf("%w: количество кругов должно быть целым числом", packet.ErrBadParam),
+		}
+	}
+	return nil
+}
+
+// swimmingDistance возвращает дистанцию заплыва в километрах.
+func swimmingDistance(w Workout) float64 {
+	return w.Params[0] * w.Params[1] / units.MetersInKm
+}
+
+// SwimmingSpentCalories возвращает количество калорий, потраченных при
+// плавании. Средняя скорость считается по длине бассейна (в метрах)
+// и количеству проплытых кругов, интенсивность определяется по ней
+// с помощью таблицы MET, поэтому обе модели расчета дают для плавания
+// одинаковый результат.
+func SwimmingSpentCalories(poolLength float64, laps int, weight float64, duration time.Duration) (float64, error) {
+	if poolLength <= 0 {
+		return 0, errors.New("длина бассейна должна быть больше нуля")
+	}
+	if laps <= 0 {
+		return 0, errors.New("количество кругов должно быть больше нуля")
+	}
+	if weight <= 0 {
+		return 0, errors.New("вес должен быть больше нуля")
+	}
+	if duration <= 0 {
+		return 0, errors.New("продолжительность должна быть больше нуля")
+	}
+
+	speed := poolLength * float64(laps) / units.MetersInKm / duration.Hours()
+	return METSpentCalories(swimmingMET.intensity(speed), weight, duration)
+}
@@ -0,0 +1,75 @@
+package spentcalories
+
+import (
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yandex-Practicum/tracker/internal/packet"
+	"github.com/Yandex-Practicum/tracker/internal/profile"
+)
+
+func (suite *SpentCaloriesTestSuite) TestSwimming() {
+	got, err := Calculate("1200,Плавание,1h00m,25,40", 75.0, 1.75)
+	require.NoError(suite.T(), err)
+
+	assert.Equal(suite.T(), "Плавание", got.Activity)
+	assert.Equal(suite.T(), time.Hour, got.Duration)
+	assert.InDelta(suite.T(), 1.0, got.Distance, 1e-9)
+	assert.InDelta(suite.T(), 1.0, got.Speed, 1e-9)
+	assert.InDelta(suite.T(), 435.0, got.Calories, 1e-9)
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "без параметров бассейна", input: "1200,Плавание,1h00m"},
+		{name: "один параметр", input: "1200,Плавание,1h00m,25"},
+		{name: "дробное количество кругов", input: "1200,Плавание,1h00m,25,40.5"},
+		{name: "отрицательная длина бассейна", input: "1200,Плавание,1h00m,-25,40"},
+		{name: "нечисловой параметр", input: "1200,Плавание,1h00m,25,много"},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			_, err := Calculate(tt.input, 75.0, 1.75)
+			assert.Error(suite.T(), err)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestSwimmingSpentCalories() {
+	// Ожидаемые значения — MET плавания в бассейне по Compendium of Physical
+	// Activities (5,8; 8,3 и 9,8) * 75 кг * 1 ч.
+	tests := []struct {
+		name string
+		laps int
+		want float64
+	}{
+		{name: "1 км/ч, MET 5.8", laps: 40, want: 435},
+		{name: "2.5 км/ч, MET 8.3", laps: 100, want: 622.5},
+		{name: "3.2 км/ч, MET 9.8", laps: 128, want: 735},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := SwimmingSpentCalories(25, tt.laps, 75, time.Hour)
+			require.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.want, got, 1e-9)
+		})
+	}
+}
+
+func (suite *SpentCaloriesTestSuite) TestSwimmingFractionalLaps() {
+	for _, input := range []string{"1200,Плавание,1h00m,25,40.5", "v2,12:00,1200,Плавание,1h00m,25,40.5,hr=140"} {
+		_, err := CalculateFor(input, profile.Profile{Weight: 75, Height: 1.75, Age: 30, Sex: profile.SexMale})
+
+		var pe *packet.ParseError
+		require.ErrorAs(suite.T(), err, &pe, input)
+		assert.ErrorIs(suite.T(), err, packet.ErrBadParam)
+		assert.Equal(suite.T(), input, pe.Input)
+		assert.Equal(suite.T(), packet.FieldParam, pe.Field)
+		assert.Equal(suite.T(), "40.5", pe.Value)
+	}
+}
@@ -0,0 +1,64 @@
+package spentcalories
+
+import (
+	"strings"
+
+	"github.com/Yandex-Practicum/tracker/internal/heartrate"
+	"github.com/Yandex-Practicum/tracker/internal/i18n"
+	"github.com/Yandex-Practicum/tracker/internal/report"
+)
+
+// ZoneReport — отчет о тренировке с распределением времени по пульсовым
+// зонам.
+type ZoneReport struct {
+	Summary TrainingSummary
+	Zones   heartrate.Zones // зоны пользователя (см. profile.Profile.Zones).
+	// Base — отчет о той же тренировке, к которому добавляются зоны,
+	// например PaceReport. Если nil, используется Summary.
+	Base report.Report
+}
+
+// base возвращает отчет, к которому добавляются зоны.
+func (r ZoneReport) base() report.Report {
+	if r.Base != nil {
+		return r.Base
+	}
+	return r.Summary
+}
+
+// Analysis распределяет время тренировки по зонам по показаниям пульса
+// из пакета.
+func (r ZoneReport) Analysis() heartrate.Analysis {
+	return heartrate.Analyze(r.Summary.HeartRates, r.Summary.Duration, r.Zones)
+}
+
+// String возвращает отчет в текстовом виде на языке по умолчанию
+// и в метрических единицах.
+func (r ZoneReport) String() string {
+	return r.Format(i18n.Locale{})
+}
+
+// Format возвращает базовый отчет о тренировке, дополненный максимальным
+// пульсом и временем в каждой зоне. Время ниже первой зоны выводится,
+// только если оно есть.
+func (r ZoneReport) Format(loc i18n.Locale) string {
+	var b strings.Builder
+	b.WriteString(r.base().Format(loc))
+
+	if len(r.Summary.HeartRates) == 0 {
+		b.WriteString(loc.Sprintf(i18n.MsgZonesNoData))
+		return b.String()
+	}
+
+	a := r.Analysis()
+	b.WriteString(loc.Sprintf(i18n.MsgMaxHeartRate, a.Max))
+	b.WriteString(loc.Sprintf(i18n.MsgZonesHeader))
+	for i, zone := range r.Zones {
+		b.WriteString(loc.Sprintf(i18n.MsgZone, zone.Number, zone.Low, zone.High, i18n.Clock(a.Time[i]), a.Share(zone.Number)*100))
+	}
+	if a.Below > 0 {
+		b.WriteString(loc.Sprintf(i18n.MsgZoneBelow, i18n.Clock(a.Below), a.Share(0)*100))
+	}
+
+	return b.String()
+}
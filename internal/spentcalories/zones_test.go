@@ -0,0 +1,86 @@
+package spentcalories
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Yandex-Practicum/tracker/internal/heartrate"
+)
+
+func (suite *SpentCaloriesTestSuite) TestHeartRateSeries() {
+	got, err := Calculate("6000,Бег,40m,hr=110/130/140/150/160/175/185/150", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{110, 130, 140, 150, 160, 175, 185, 150}, got.HeartRates)
+	assert.Equal(suite.T(), 150, got.HeartRate)
+
+	_, err = Calculate("6000,Бег,40m,hr=110//150", 75, 1.75)
+	assert.Error(suite.T(), err)
+}
+
+func (suite *SpentCaloriesTestSuite) TestZoneReport() {
+	zones, err := heartrate.MaxBased.Zones(190, 0)
+	require.NoError(suite.T(), err)
+
+	s, err := Calculate("6000,Бег,40m,hr=80/130/140/150/160/175/185/150", 75, 1.75)
+	require.NoError(suite.T(), err)
+
+	r := ZoneReport{Summary: s, Zones: zones}
+	assert.Equal(suite.T(), s.String()+
+		"Максимальный пульс: 185 уд/мин\n"+
+		"Пульсовые зоны:\n"+
+		"  Зона 1 (95–114 уд/мин): 0:00 (0%)\n"+
+		"  Зона 2 (114–133 уд/мин): 5:00 (12%)\n"+
+		"  Зона 3 (133–152 уд/мин): 15:00 (38%)\n"+
+		"  Зона 4 (152–171 уд/мин): 5:00 (12%)\n"+
+		"  Зона 5 (171–190 уд/мин): 10:00 (25%)\n"+
+		"  Ниже зоны 1: 5:00 (12%)\n", r.String())
+
+	var got struct {
+		MaxHeartRate int `json:"heart_rate_max_bpm"`
+		Zones        []struct {
+			Zone     int     `json:"zone"`
+			Duration float64 `json:"duration_s"`
+			Share    float64 `json:"share"`
+		} `json:"zones"`
+		Below float64 `json:"below_zones_s"`
+	}
+	data, err := json.Marshal(r)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.Equal(suite.T(), 185, got.MaxHeartRate)
+	require.Len(suite.T(), got.Zones, heartrate.Count)
+	assert.Equal(suite.T(), 900.0, got.Zones[2].Duration)
+	assert.InDelta(suite.T(), 0.375, got.Zones[2].Share, 1e-9)
+	assert.Equal(suite.T(), 300.0, got.Below)
+
+	plain, err := Calculate("6000,Бег,40m", 75, 1.75)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), plain.String()+"Нет данных о пульсе.\n", ZoneReport{Summary: plain, Zones: zones}.String())
+}
+
+func (suite *SpentCaloriesTestSuite) TestZoneReportWithPace() {
+	zones, err := heartrate.MaxBased.Zones(190, 0)
+	require.NoError(suite.T(), err)
+
+	s, err := Calculate("6000,Бег,40m,hr=80/130/140/150/160/175/185/150", 75, 1.75)
+	require.NoError(suite.T(), err)
+
+	pace := PaceReport{Summary: s}
+	r := ZoneReport{Summary: s, Zones: zones, Base: pace}
+	text := r.String()
+	assert.True(suite.T(), strings.HasPrefix(text, pace.String()), text)
+	assert.Contains(suite.T(), text, "Пульсовые зоны:\n")
+
+	var got map[string]any
+	data, err := json.Marshal(r)
+	require.NoError(suite.T(), err)
+	require.NoError(suite.T(), json.Unmarshal(data, &got))
+	assert.Contains(suite.T(), got, "pace_s_per_km")
+	assert.Contains(suite.T(), got, "splits")
+	assert.Contains(suite.T(), got, "zones")
+	assert.Equal(suite.T(), "Бег", got["activity"])
+	assert.Equal(suite.T(), float64(185), got["heart_rate_max_bpm"])
+}
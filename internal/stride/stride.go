@@ -0,0 +1,68 @@
+// Package stride содержит модели длины шага и расчет дистанции по шагам,
+// общие для дневной активности и тренировок.
+package stride
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Yandex-Practicum/tracker/internal/units"
+)
+
+// Strategy — способ определения длины шага.
+type Strategy string
+
+const (
+	// Fixed — фиксированная средняя длина шага FixedStepLength.
+	Fixed Strategy = "fixed"
+	// HeightBased — длина шага пропорциональна росту: рост * HeightCoefficient.
+	HeightBased Strategy = "height"
+	// Calibrated — длина шага, измеренная пользователем.
+	Calibrated Strategy = "calibrated"
+)
+
+const (
+	// FixedStepLength — средняя длина шага в метрах.
+	FixedStepLength = 0.65
+	// HeightCoefficient — коэффициент для расчета длины шага на основе роста.
+	HeightCoefficient = 0.45
+)
+
+// Parse возвращает стратегию по её названию. Пустая строка допустима
+// и означает стратегию по умолчанию вызывающего пакета.
+func Parse(s string) (Strategy, error) {
+	switch Strategy(s) {
+	case "", Fixed, HeightBased, Calibrated:
+		return Strategy(s), nil
+	default:
+		return "", fmt.Errorf("неизвестная модель длины шага %q: ожидается fixed, height или calibrated", s)
+	}
+}
+
+// StepLength возвращает длину шага в метрах. height — рост в метрах,
+// calibrated — откалиброванная длина шага; каждое значение нужно только
+// соответствующей стратегии.
+func (s Strategy) StepLength(height, calibrated float64) (float64, error) {
+	switch s {
+	case Fixed:
+		return FixedStepLength, nil
+	case HeightBased:
+		if height <= 0 {
+			return 0, errors.New("рост должен быть больше нуля")
+		}
+		return height * HeightCoefficient, nil
+	case Calibrated:
+		if calibrated <= 0 {
+			return 0, errors.New("не задана откалиброванная длина шага")
+		}
+		return calibrated, nil
+	default:
+		return 0, fmt.Errorf("неизвестная модель длины шага %q", s)
+	}
+}
+
+// Distance возвращает дистанцию в километрах для указанного количества
+// шагов и длины шага в метрах.
+func Distance(steps int, stepLength float64) float64 {
+	return float64(steps) * stepLength / units.MetersInKm
+}
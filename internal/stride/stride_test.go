@@ -0,0 +1,57 @@
+package stride
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StrideTestSuite struct {
+	suite.Suite
+}
+
+func TestStrideSuite(t *testing.T) {
+	suite.Run(t, new(StrideTestSuite))
+}
+
+func (suite *StrideTestSuite) TestStepLength() {
+	tests := []struct {
+		name       string
+		strategy   Strategy
+		height     float64
+		calibrated float64
+		want       float64
+		wantErr    bool
+	}{
+		{name: "фиксированная", strategy: Fixed, height: 1.75, want: 0.65},
+		{name: "по росту", strategy: HeightBased, height: 1.75, want: 0.7875},
+		{name: "по росту без роста", strategy: HeightBased, wantErr: true},
+		{name: "откалиброванная", strategy: Calibrated, height: 1.75, calibrated: 0.8, want: 0.8},
+		{name: "откалиброванная без значения", strategy: Calibrated, height: 1.75, wantErr: true},
+		{name: "неизвестная", strategy: "other", height: 1.75, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := tt.strategy.StepLength(tt.height, tt.calibrated)
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+			assert.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.want, got, 1e-9)
+		})
+	}
+}
+
+func (suite *StrideTestSuite) TestParseAndDistance() {
+	s, err := Parse("height")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), HeightBased, s)
+
+	_, err = Parse("stride")
+	assert.Error(suite.T(), err)
+
+	assert.InDelta(suite.T(), 3.9, Distance(6000, FixedStepLength), 1e-9)
+}
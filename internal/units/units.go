@@ -0,0 +1,146 @@
+// Package units содержит системы единиц измерения и все переводы между
+// ними. Внутри трекера значения хранятся в метрической системе: килограммы,
+// метры, километры и км/ч; перевод выполняется только на вводе и выводе.
+package units
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// System — система единиц измерения.
+type System string
+
+const (
+	Metric   System = "metric"   // килограммы, метры, километры.
+	Imperial System = "imperial" // фунты, футы и дюймы, мили.
+)
+
+// Коэффициенты перевода.
+const (
+	MetersInKm   = 1000       // количество метров в километре.
+	KmInMile     = 1.609344   // количество километров в миле.
+	KgInPound    = 0.45359237 // количество килограммов в фунте.
+	MetersInFoot = 0.3048     // количество метров в футе.
+	MetersInInch = 0.0254     // количество метров в дюйме.
+	InchesInFoot = 12         // количество дюймов в футе.
+)
+
+// Parse возвращает систему единиц по названию. Пустая строка означает Metric.
+func Parse(s string) (System, error) {
+	switch System(s) {
+	case "", Metric:
+		return Metric, nil
+	case Imperial:
+		return Imperial, nil
+	default:
+		return "", fmt.Errorf("неизвестная система единиц %q: ожидается metric или imperial", s)
+	}
+}
+
+// Distance переводит дистанцию из километров в единицы системы.
+func (s System) Distance(km float64) float64 {
+	if s == Imperial {
+		return km / KmInMile
+	}
+	return km
+}
+
+// Kilometers переводит дистанцию из единиц системы в километры.
+func (s System) Kilometers(v float64) float64 {
+	if s == Imperial {
+		return v * KmInMile
+	}
+	return v
+}
+
+// Speed переводит скорость из км/ч в единицы системы.
+func (s System) Speed(kmh float64) float64 {
+	return s.Distance(kmh)
+}
+
+// DistanceUnit возвращает обозначение единицы дистанции: "km" или "mi".
+func (s System) DistanceUnit() string {
+	if s == Imperial {
+		return "mi"
+	}
+	return "km"
+}
+
+// SpeedUnit возвращает обозначение единицы скорости: "km/h" или "mph".
+func (s System) SpeedUnit() string {
+	if s == Imperial {
+		return "mph"
+	}
+	return "km/h"
+}
+
+// Pace переводит темп из времени на километр во время на единицу
+// дистанции системы.
+func (s System) Pace(perKm time.Duration) time.Duration {
+	if s == Imperial {
+		return time.Duration(float64(perKm) * KmInMile)
+	}
+	return perKm
+}
+
+// PaceUnit возвращает обозначение единицы темпа: "min/km" или "min/mi".
+func (s System) PaceUnit() string {
+	if s == Imperial {
+		return "min/mi"
+	}
+	return "min/km"
+}
+
+// Weight переводит вес из единиц системы в килограммы.
+func (s System) Weight(v float64) float64 {
+	if s == Imperial {
+		return v * KgInPound
+	}
+	return v
+}
+
+// Length переводит длину (рост, длину шага) из единиц системы в метры.
+// В имперской системе значение задается в дюймах.
+func (s System) Length(v float64) float64 {
+	if s == Imperial {
+		return v * MetersInInch
+	}
+	return v
+}
+
+// feetInches разбирает рост вида 5'11", 5'11, 5ft11in, 5ft или 71in.
+var feetInches = regexp.MustCompile(`^(?:(\d+(?:\.\d+)?)\s*(?:'|ft))?\s*(?:(\d+(?:\.\d+)?)\s*(?:"|in)?)?$`)
+
+// ParseLength разбирает длину в единицах системы и возвращает её в метрах.
+// В метрической системе ожидается число метров. В имперской — футы
+// и дюймы (5'11", 5ft11in, 71in); число без обозначений считается дюймами.
+func (s System) ParseLength(str string) (float64, error) {
+	str = strings.TrimSpace(str)
+	if s != Imperial {
+		v, err := strconv.ParseFloat(str, 64)
+		if err != nil {
+			return 0, fmt.Errorf("неверная длина %q: %w", str, err)
+		}
+		return v, nil
+	}
+
+	m := feetInches.FindStringSubmatch(str)
+	if str == "" || m == nil || (m[1] == "" && m[2] == "") {
+		return 0, fmt.Errorf("неверная длина %q: ожидается 5'11\", 5ft11in или 71in", str)
+	}
+
+	var inches float64
+	if m[1] != "" {
+		feet, _ := strconv.ParseFloat(m[1], 64)
+		inches += feet * InchesInFoot
+	}
+	if m[2] != "" {
+		in, _ := strconv.ParseFloat(m[2], 64)
+		inches += in
+	}
+	return inches * MetersInInch, nil
+}
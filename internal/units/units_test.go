@@ -0,0 +1,67 @@
+package units
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitsTestSuite struct {
+	suite.Suite
+}
+
+func TestUnitsSuite(t *testing.T) {
+	suite.Run(t, new(UnitsTestSuite))
+}
+
+func (suite *UnitsTestSuite) TestConversions() {
+	assert.InDelta(suite.T(), 10, Metric.Distance(10), 1e-9)
+	assert.InDelta(suite.T(), 6.2137, Imperial.Distance(10), 1e-4)
+	assert.InDelta(suite.T(), 3.1069, Imperial.Speed(5), 1e-4)
+	assert.InDelta(suite.T(), 84.368, Imperial.Weight(186), 1e-3)
+	assert.InDelta(suite.T(), 10, Imperial.Kilometers(Imperial.Distance(10)), 1e-9)
+	assert.InDelta(suite.T(), 0.762, Imperial.Length(30), 1e-9)
+	assert.Equal(suite.T(), "mi", Imperial.DistanceUnit())
+	assert.Equal(suite.T(), "km/h", Metric.SpeedUnit())
+	assert.Equal(suite.T(), 5*time.Minute, Metric.Pace(5*time.Minute))
+	assert.Equal(suite.T(), 8*time.Minute+2*time.Second+803200*time.Microsecond, Imperial.Pace(5*time.Minute))
+	assert.Equal(suite.T(), "min/mi", Imperial.PaceUnit())
+}
+
+func (suite *UnitsTestSuite) TestParseLength() {
+	tests := []struct {
+		name    string
+		system  System
+		input   string
+		want    float64
+		wantErr bool
+	}{
+		{name: "метры", system: Metric, input: "1.75", want: 1.75},
+		{name: "футы и дюймы", system: Imperial, input: `5'11"`, want: 71 * MetersInInch},
+		{name: "футы и дюймы без кавычек", system: Imperial, input: "5'11", want: 71 * MetersInInch},
+		{name: "ft и in", system: Imperial, input: "5ft 11in", want: 71 * MetersInInch},
+		{name: "только футы", system: Imperial, input: "6ft", want: 72 * MetersInInch},
+		{name: "только дюймы", system: Imperial, input: "71", want: 71 * MetersInInch},
+		{name: "мусор", system: Imperial, input: "tall", wantErr: true},
+		{name: "пусто", system: Imperial, input: "", wantErr: true},
+		{name: "метры с ошибкой", system: Metric, input: "1,75", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			got, err := tt.system.ParseLength(tt.input)
+			if tt.wantErr {
+				assert.Error(suite.T(), err)
+				return
+			}
+			require.NoError(suite.T(), err)
+			assert.InDelta(suite.T(), tt.want, got, 1e-9)
+		})
+	}
+
+	_, err := Parse("si")
+	assert.Error(suite.T(), err)
+}